@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Profile defines how accurate is computer player in aiming.
+//
+// Solutions found by Solver are precise so some random error is added to them.
+// Error is smaller for more difficult profiles.
+// When Learning is enabled each next shot to the same target has smaller error.
+type Profile struct {
+	// Name of the profile
+	Name string
+	// AngleError is maximal number of degrees which can be added to or subtracted from solved angle
+	AngleError int
+	// PowerError is maximal amount of power which can be added to or subtracted from solved power
+	PowerError int
+	// Learning if true allows to correct aim by previous shots to the same target
+	Learning bool
+}
+
+// DefaultProfile is name of the profile used when no profile is specified
+const DefaultProfile = "normal"
+
+// profiles holds all available profiles by their names
+var profiles = map[string]*Profile{
+	"easy":    {Name: "easy", AngleError: 8, PowerError: 10},
+	"normal":  {Name: "normal", AngleError: 4, PowerError: 6, Learning: true},
+	"hard":    {Name: "hard", AngleError: 2, PowerError: 3, Learning: true},
+	"perfect": {Name: "perfect", Learning: true},
+}
+
+// ProfileByName returns profile with given name.
+// Empty name is considered as DefaultProfile.
+func ProfileByName(name string) (*Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+	p := profiles[strings.ToLower(name)]
+	if p == nil {
+		return nil, fmt.Errorf("Unknown AI profile '%s', use one of: %s", name, strings.Join(ProfileNames(), ", "))
+	}
+	return p, nil
+}
+
+// ProfileNames returns names of all available profiles sorted alphabetically
+func ProfileNames() []string {
+	names := []string{}
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Distort adds random error to given solution.
+// Error is scaled by given focus which should be number between 0 and 1.
+// Focus 1 means full error defined by profile, focus 0 means no error.
+func (p *Profile) Distort(s Solution, focus float64, rnd *rand.Rand) Solution {
+	s.Angle = gmath.Clamp(0, 180, s.Angle+randomError(p.AngleError, focus, rnd))
+	s.Power = gmath.Max(1, s.Power+randomError(p.PowerError, focus, rnd))
+	return s
+}
+
+// randomError returns random number from range -max*focus to +max*focus
+func randomError(max int, focus float64, rnd *rand.Rand) int {
+	m := int(float64(max) * focus)
+	if m <= 0 {
+		return 0
+	}
+	return rnd.Intn(m*2+1) - m
+}
@@ -0,0 +1,156 @@
+// Package ai contains logic used by computer controlled players.
+//
+// The main part is Solver which is able to find angle and power needed for hitting some target.
+// It does not depend on game engine, it just simulates bullet flight using the same physical model as the game world.
+package ai
+
+import (
+	"math"
+
+	"github.com/zladovan/gorched/gmath"
+	"github.com/zladovan/gorched/physics"
+)
+
+// Solver finds angle and power needed for hitting some target by simulating trajectories of bullets.
+type Solver struct {
+	// Physics is physical model used for the simulation of bullet flight.
+	// Its Ground function is used to detect hits of the terrain.
+	Physics *physics.Physics
+	// Width of the world
+	Width int
+	// Height of the world
+	Height int
+	// Start returns initial position of the bullet for given angle of the cannon
+	Start func(angle int) gmath.Vector2i
+	// Origin is position of the shooter, solutions ending too close to it are avoided
+	Origin gmath.Vector2f
+	// SafeDistance is minimal distance of explosion from Origin which is considered as safe
+	SafeDistance float64
+	// MaxPower is maximal shooting power which can be used
+	MaxPower int
+	// Step is time step in seconds used for the simulation
+	Step float64
+}
+
+// Solution holds angle and power which should be used to hit the target.
+type Solution struct {
+	// Angle is the angle of the cannon
+	Angle int
+	// Power is the shooting power
+	Power int
+	// Miss is the shortest distance between simulated trajectory and the target
+	Miss float64
+}
+
+// Shot is result of simulated bullet flight.
+type Shot struct {
+	// Path holds all positions of the bullet during flight
+	Path []gmath.Vector2f
+	// Lost is true if bullet left the world without hitting anything
+	Lost bool
+}
+
+// Impact returns last position of the bullet
+func (s *Shot) Impact() gmath.Vector2f {
+	return s.Path[len(s.Path)-1]
+}
+
+// maxFlightTime is maximal time in seconds for which will be one bullet simulated
+const maxFlightTime = 30
+
+// Solve finds solution with trajectory going as close as possible to the target.
+// Only angles pointing towards the target are tried.
+// Search is done in two phases, first with coarse steps and then with fine steps around the best coarse solution.
+func (s *Solver) Solve(target gmath.Vector2f) Solution {
+	minAngle, maxAngle := 0, 90
+	if target.X < s.Origin.X {
+		minAngle, maxAngle = 90, 180
+	}
+
+	best := s.search(target, minAngle, maxAngle, 3, 1, s.MaxPower, 3)
+	return s.search(
+		target,
+		gmath.Max(minAngle, best.Angle-3), gmath.Min(maxAngle, best.Angle+3), 1,
+		gmath.Max(1, best.Power-3), gmath.Min(s.MaxPower, best.Power+3), 1,
+	)
+}
+
+// search tries all combinations of angles and powers in given ranges and returns the best one
+func (s *Solver) search(target gmath.Vector2f, minAngle, maxAngle, angleStep, minPower, maxPower, powerStep int) Solution {
+	best := Solution{Angle: minAngle, Power: minPower, Miss: math.Inf(1)}
+	for angle := minAngle; angle <= maxAngle; angle += angleStep {
+		for power := minPower; power <= maxPower; power += powerStep {
+			miss := s.miss(s.Simulate(angle, power), target)
+			if miss < best.Miss {
+				best = Solution{Angle: angle, Power: power, Miss: miss}
+			}
+		}
+	}
+	return best
+}
+
+// miss calculates how far is given shot from the target.
+// Lost shots and shots which would explode too close to the shooter are penalized.
+func (s *Solver) miss(shot Shot, target gmath.Vector2f) float64 {
+	if shot.Lost {
+		return math.Inf(1)
+	}
+	miss := math.Inf(1)
+	for _, p := range shot.Path {
+		miss = math.Min(miss, p.Distance(&target))
+	}
+	impact := shot.Impact()
+	if impact.Distance(&s.Origin) < s.SafeDistance {
+		miss += float64(s.Width)
+	}
+	return miss
+}
+
+// Simulate simulates flight of the bullet shot with given angle and power.
+// Simulation ends when bullet hits the ground or leaves the world.
+func (s *Solver) Simulate(angle, power int) Shot {
+	start := s.Start(angle)
+	theta := 2.0 * math.Pi * (float64(angle) / 360.0)
+	b := &bullet{body: &physics.Body{
+		Position: *start.As2F(),
+		Velocity: gmath.Vector2f{X: math.Cos(theta) * float64(power), Y: math.Sin(theta) * -float64(power)},
+		Mass:     1,
+	}}
+
+	shot := Shot{}
+	for t := 0.0; t < maxFlightTime; t += s.Step {
+		y := int(b.body.Position.Y)
+		s.Physics.Apply(b, s.Step)
+		p := b.body.Position
+		shot.Path = append(shot.Path, p)
+
+		// bullet is lost if it's below the screen or too far on the left/right of the screen
+		if int(p.Y) > s.Height || int(p.X) < -100 || int(p.X) > s.Width+100 {
+			shot.Lost = true
+			return shot
+		}
+
+		// check if bullet hit the ground
+		if p.X >= 0 && int(p.X) < s.Width && int(p.Y) >= s.Physics.Ground(int(p.X), y) {
+			return shot
+		}
+	}
+
+	shot.Lost = true
+	return shot
+}
+
+// bullet is simplified bullet used only for simulation
+type bullet struct {
+	body *physics.Body
+}
+
+// Body returns physical body of the bullet
+func (b *bullet) Body() *physics.Body {
+	return b.body
+}
+
+// Windage returns 1 as bullet is fully affected by the wind
+func (b *bullet) Windage() float64 {
+	return 1
+}
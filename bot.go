@@ -0,0 +1,273 @@
+package gorched
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/zladovan/gorched/ai"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/debug"
+	"github.com/zladovan/gorched/entities"
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Bot is entity controlling tanks of computer players.
+// It uses Controls to do the same actions as human player would do with keyboard.
+// Angle and power are found by ai.Solver when computer player is on turn.
+// Found solution is then distorted by the player's ai.Profile.
+//
+// If profile allows learning, bot remembers where his previous shots were expected to end and where they really ended.
+// Difference is used to correct the aim of the next shot to the same target.
+// Additionally the error added by profile is reduced with each next shot to the same target.
+type Bot struct {
+	// game refers to the main game object
+	game *Game
+	// tank is the tank for which is the plan prepared
+	tank *entities.Tank
+	// plan holds angle and power which will be used in current turn, it's nil when not prepared yet
+	plan *ai.Solution
+	// wait is number of seconds remaining until next action
+	wait float64
+	// pointsSpent is flag for marking that attribute points and money were already spent in finished round
+	pointsSpent bool
+	// memories holds memory for each computer player
+	memories map[*core.Player]*botMemory
+	// rnd is source of random errors
+	rnd *rand.Rand
+}
+
+// botMemory holds what bot remembers about previous shots of one player
+type botMemory struct {
+	// target is the tank which was targeted by previous shot
+	target *entities.Tank
+	// expected is the point where previous shot was expected to end
+	expected gmath.Vector2f
+	// correction is offset on x axis which is added to the target position to correct the aim
+	correction float64
+	// focus scales error of the profile, it's decreasing with each next shot to the same target
+	focus float64
+	// shots is number of shots to the target
+	shots int
+}
+
+// constants controlling how fast bot is acting
+const (
+	// botThinkTime is number of seconds which bot waits on start of his turn
+	botThinkTime = 0.5
+	// botRotateTime is number of seconds between two changes of the cannon angle
+	botRotateTime = 0.01
+	// botCloseFormTime is number of seconds after which forms are closed when all players are computers
+	botCloseFormTime = 2
+	// botFocusDecay is multiplier applied to focus after each shot to the same target
+	botFocusDecay = 0.6
+)
+
+// NewBot creates bot for all computer players in given game
+func NewBot(game *Game) *Bot {
+	return &Bot{
+		game:     game,
+		memories: map[*core.Player]*botMemory{},
+		rnd:      rand.New(rand.NewSource(game.options.Seed)),
+	}
+}
+
+// Update performs bot actions, it is called in each simulation step
+func (b *Bot) Update() {
+	round := b.game.round
+	b.wait -= entities.Step
+
+	// spend points and money gained in finished round, forms are shown right after the round is finished
+	if round.IsFinished() {
+		if !b.pointsSpent {
+			b.spendPoints()
+			b.spendMoney()
+			b.pointsSpent = true
+		}
+	} else {
+		b.pointsSpent = false
+	}
+
+	// forms are closed automatically only when there is no human to do it
+	if b.game.Hud().IsFormShown() {
+		if b.allComputers() && b.wait <= 0 {
+			b.game.controls.HideMessageBox()
+			b.wait = botCloseFormTime
+		}
+		return
+	}
+
+	// nothing more to do until the next round
+	if round.IsFinished() {
+		return
+	}
+
+	// forget plan when turn is over
+	if !round.IsPlayerOnTurn() {
+		b.plan = nil
+		return
+	}
+
+	// nothing to do when human is on turn
+	tank := round.ActiveTank()
+	if !tank.Player().IsComputer() {
+		return
+	}
+
+	// prepare new plan at the start of the turn
+	if b.plan == nil || b.tank != tank {
+		b.tank = tank
+		b.plan = b.solve(tank)
+		b.wait = botThinkTime
+	}
+	if b.plan == nil || b.wait > 0 {
+		return
+	}
+
+	switch {
+	case tank.IsIdle() && tank.Angle() < b.plan.Angle:
+		b.game.controls.MoveUp()
+		b.wait = botRotateTime
+	case tank.IsIdle() && tank.Angle() > b.plan.Angle:
+		b.game.controls.MoveDown()
+		b.wait = botRotateTime
+	case tank.IsIdle():
+		b.game.controls.Shoot()
+	case tank.IsLoading() && tank.Power() >= b.plan.Power:
+		b.game.controls.Shoot()
+	}
+}
+
+// solve finds angle and power for given tank to hit the nearest enemy.
+// It returns nil if there is no enemy.
+func (b *Bot) solve(tank *entities.Tank) *ai.Solution {
+	target := b.target(tank)
+	if target == nil {
+		return nil
+	}
+
+	player := tank.Player()
+	profile, err := ai.ProfileByName(player.Difficulty)
+	if err != nil {
+		profile, _ = ai.ProfileByName(ai.DefaultProfile)
+	}
+
+	world := b.game.round.World()
+	width, height := world.Size()
+	maxPower := player.Attributes.Power() - 1
+	solver := &ai.Solver{
+		Physics:      world.Physics(),
+		Width:        width,
+		Height:       height,
+		Start:        tank.BulletPosition,
+		Origin:       tank.Center(),
+		SafeDistance: float64(player.Attributes.Explosion() + 3),
+		MaxPower:     maxPower,
+		Step:         entities.Step,
+	}
+
+	// learn from the previous shot to the same target
+	memory := b.memory(player, target)
+	if profile.Learning && memory.shots > 0 && player.LastShot != nil && !player.LastShot.Lost {
+		memory.correction += memory.expected.X - float64(player.LastShot.X)
+		memory.focus *= botFocusDecay
+	}
+
+	// find precise solution for corrected target and distort it by profile
+	aim := target.Center()
+	aim.X += memory.correction
+	solution := profile.Distort(solver.Solve(aim), memory.focus, b.rnd)
+	solution.Power = gmath.Min(maxPower, solution.Power)
+
+	// remember where this shot should end
+	shot := solver.Simulate(solution.Angle, solution.Power)
+	memory.expected = shot.Impact()
+	memory.shots++
+	player.LastShot = nil
+
+	debug.Logf("Bot solved angle=%d power=%d correction=%f focus=%f", solution.Angle, solution.Power, memory.correction, memory.focus)
+
+	return &solution
+}
+
+// memory returns memory of given player.
+// Memory is reset when player changes the target.
+func (b *Bot) memory(player *core.Player, target *entities.Tank) *botMemory {
+	m := b.memories[player]
+	if m == nil || m.target != target {
+		m = &botMemory{target: target, focus: 1}
+		b.memories[player] = m
+	}
+	return m
+}
+
+// target finds nearest enemy tank which is still alive
+func (b *Bot) target(tank *entities.Tank) *entities.Tank {
+	var target *entities.Tank
+	distance := math.Inf(1)
+	for _, t := range b.game.round.Tanks() {
+		if t == tank || !t.IsAlive() {
+			continue
+		}
+		d := math.Abs(t.Center().X - tank.Center().X)
+		if d < distance {
+			target = t
+			distance = d
+		}
+	}
+	return target
+}
+
+// spendPoints distributes attribute points of all computer players equally between attack and defense
+func (b *Bot) spendPoints() {
+	for _, p := range b.game.players {
+		if !p.IsComputer() {
+			continue
+		}
+		for ; p.Attributes.Points > 0; p.Attributes.Points-- {
+			if p.Attributes.Attack <= p.Attributes.Defense {
+				p.Attributes.Attack++
+			} else {
+				p.Attributes.Defense++
+			}
+		}
+	}
+}
+
+// spendMoney lets all computer players buy items from the shop.
+// Weapons are not bought as bot doesn't switch them.
+// Player buys the strongest shield which he can afford if it's stronger than his shields and a parachute if he has none.
+func (b *Bot) spendMoney() {
+	for _, p := range b.game.players {
+		if !p.IsComputer() {
+			continue
+		}
+		for _, s := range core.Shields {
+			if p.Inventory.Has(s.Name) || b.buy(p, s.Name) {
+				break
+			}
+		}
+		if !p.Inventory.Has(core.ParachuteItem) {
+			b.buy(p, core.ParachuteItem)
+		}
+	}
+}
+
+// buy buys goods with given name from the shop for given player, it returns false if player can not buy it
+func (b *Bot) buy(p *core.Player, name string) bool {
+	for _, g := range entities.Goods {
+		if g.Name == name {
+			return p.Buy(g)
+		}
+	}
+	return false
+}
+
+// allComputers returns true if there is no human player in the game
+func (b *Bot) allComputers() bool {
+	for _, p := range b.game.players {
+		if !p.IsComputer() {
+			return false
+		}
+	}
+	return true
+}
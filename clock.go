@@ -0,0 +1,53 @@
+package gorched
+
+import (
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/entities"
+)
+
+// maxFrameTime is maximal number of seconds from one frame which will be simulated.
+// It avoids spiral of death when simulation is slower than real time.
+const maxFrameTime = 0.25
+
+// Clock is entity driving the game simulation.
+// Time passed between frames is accumulated and simulation is advanced by fixed entities.Step as many times as it fits into accumulated time.
+// It makes results of the simulation independent on the frame rate.
+type Clock struct {
+	// game refers to the main game object
+	game *Game
+	// accumulator holds number of seconds which were not simulated yet
+	accumulator float64
+	// ticks is number of simulation steps done since the start of the game
+	ticks int64
+}
+
+// NewClock creates clock for given game
+func NewClock(game *Game) *Clock {
+	return &Clock{game: game}
+}
+
+// Draw advances simulation by the time passed from the previous frame
+func (c *Clock) Draw(s *tl.Screen) {
+	c.accumulator += s.TimeDelta()
+	if c.accumulator > maxFrameTime {
+		c.accumulator = maxFrameTime
+	}
+	for c.accumulator >= entities.Step {
+		c.Step()
+		c.accumulator -= entities.Step
+	}
+}
+
+// Step advances simulation by one fixed step
+func (c *Clock) Step() {
+	c.game.update()
+	c.ticks++
+}
+
+// Tick does nothing now
+func (c *Clock) Tick(e tl.Event) {}
+
+// Ticks returns number of simulation steps done since the start of the game
+func (c *Clock) Ticks() int64 {
+	return c.ticks
+}
@@ -1,17 +1,41 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
+	tl "github.com/JoelOtter/termloop"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
 	"github.com/zladovan/gorched"
+	"github.com/zladovan/gorched/ai"
+	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/demo"
+	"github.com/zladovan/gorched/entities"
+	"github.com/zladovan/gorched/keys"
+	"github.com/zladovan/gorched/network"
+	"github.com/zladovan/gorched/profile"
+	"github.com/zladovan/gorched/server"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+// default size of the game world in headless mode
+const (
+	headlessWidth  = 100
+	headlessHeight = 40
+)
+
+// defaultSavePath is path of the file where the game is saved when no other file is given
+const defaultSavePath = "gorched.save"
+
 func main() {
 	app := &cli.App{
 		Name:  "gorched",
@@ -22,49 +46,189 @@ func main() {
 		Start with --seed to play the same sequence of rounds again.
 		After finishing game you will see initial seed and seed for the last round.`,
 		Flags: []cli.Flag{
-			&cli.Int64Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "Read default values of the flags from given TOML or YAML `FILE`, flags given on the command line override them",
+				DefaultText: "~/.config/gorched/config.toml",
+			},
+			&cli.BoolFlag{
+				Name:   "no-config",
+				Usage:  "Do not read any configuration file, it's used for games started by the SSH server",
+				Hidden: true,
+			},
+			altsrc.NewInt64Flag(&cli.Int64Flag{
 				Name:        "seed",
 				Usage:       "Integer `NUMBER` used as seed for random generations",
 				DefaultText: "current time",
 				Aliases:     []string{"s"},
-			},
-			&cli.IntFlag{
+			}),
+			altsrc.NewIntFlag(&cli.IntFlag{
 				Name:        "width",
 				Usage:       "Width of the game world in `NUMBER` of console cells",
 				DefaultText: "actual terminal width",
-			},
-			&cli.IntFlag{
+			}),
+			altsrc.NewIntFlag(&cli.IntFlag{
 				Name:        "height",
 				Usage:       "Height of the game world in `NUMBER` of console cells",
 				DefaultText: "actual terminal height",
-			},
-			&cli.IntFlag{
+			}),
+			altsrc.NewIntFlag(&cli.IntFlag{
+				Name:    "players",
+				Usage:   "`NUMBER` of players in the game, it can be from 2 to 8",
+				Value:   2,
+				Aliases: []string{"p"},
+			}),
+			altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+				Name:  "ai",
+				Usage: "Player `SLOT[:PROFILE]` controlled by computer, e.g. 2:hard, can be used multiple times. Profile can be one of: " + strings.Join(ai.ProfileNames(), ", "),
+			}),
+			altsrc.NewStringFlag(&cli.StringFlag{
+				Name:  "wind",
+				Usage: "Wind `MODE`, it can be one of: none, constant (changes only between rounds), changing (changes each turn)",
+				Value: "none",
+			}),
+			altsrc.NewFloat64Flag(&cli.Float64Flag{
+				Name:  "interest",
+				Usage: "Interest rate in `PERCENT` added to the money left to the next round",
+			}),
+			altsrc.NewIntFlag(&cli.IntFlag{
 				Name:  "fps",
 				Usage: "Screen framerate, use lower values to reduce system resources usage",
 				Value: 40,
-			},
-			&cli.BoolFlag{
+			}),
+			altsrc.NewBoolFlag(&cli.BoolFlag{
 				Name:  "ascii-only",
 				Usage: "Use only ASCII characters to draw graphics",
-			},
-			&cli.BoolFlag{
+			}),
+			altsrc.NewBoolFlag(&cli.BoolFlag{
 				Name:  "low-color",
 				Usage: "Use only 8 colors to draw graphics",
-			},
-			&cli.BoolFlag{
+			}),
+			altsrc.NewBoolFlag(&cli.BoolFlag{
 				Name:  "browser",
 				Usage: "Use this flag when starting from emulated terminal in browser",
-			},
-			&cli.BoolFlag{
+			}),
+			altsrc.NewBoolFlag(&cli.BoolFlag{
 				Name:  "debug",
 				Usage: "Turn on debug mode",
+			}),
+			altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "`NAMES` of players in the order of their slots, empty name keeps the default one",
+			}),
+			altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+				Name:  "colors",
+				Usage: "`COLORS` of players' tanks in the order of their slots, empty color keeps the default one. Color can be one of: black, red, green, yellow, blue, magenta, cyan, white, optionally prefixed by bright-",
+			}),
+			altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+				Name:  "keys",
+				Usage: "Key binding `[SLOT:]COMMAND=KEY`, e.g. shoot=Enter, can be used multiple times. With slot the key is bound only for given player. Command can be one of: " + strings.Join(commandNames(), ", "),
+			}),
+			altsrc.NewFloat64Flag(&cli.Float64Flag{
+				Name:  "gravity",
+				Usage: "Gravitational `ACCELERATION` pulling bullets and tanks down",
+				Value: entities.DefaultTunables.Gravity,
+			}),
+			altsrc.NewFloat64Flag(&cli.Float64Flag{
+				Name:  "roughness",
+				Usage: "`ROUGHNESS` of the terrain, higher values make more hills and valleys",
+				Value: entities.DefaultTunables.Roughness,
+			}),
+			altsrc.NewFloat64Flag(&cli.Float64Flag{
+				Name:  "tree-density",
+				Usage: "`DENSITY` of the trees, it can be from 0 to 1",
+				Value: entities.DefaultTunables.TreeDensity,
+			}),
+			altsrc.NewIntFlag(&cli.IntFlag{
+				Name:  "tree-size",
+				Usage: "Maximal `HEIGHT` of the trees",
+				Value: entities.DefaultTunables.TreeSize,
+			}),
+			&cli.StringFlag{
+				Name:  "host",
+				Usage: "Host network game on given `ADDRESS` (e.g. :7777), all human players except the first one will be remote players",
+			},
+			&cli.StringFlag{
+				Name:  "join",
+				Usage: "Join network game hosted on given `ADDRESS` (e.g. localhost:7777)",
+			},
+			&cli.BoolFlag{
+				Name:  "spectate",
+				Usage: "Join network game only as spectator, use it together with --join",
 			},
 			&cli.StringFlag{
 				Name:  "demo",
 				Usage: "Play demo script from given `FILE` right after game start",
 			},
+			&cli.StringFlag{
+				Name:  "record",
+				Usage: "Record all input events of the game to given `FILE`",
+			},
+			&cli.StringFlag{
+				Name:  "replay",
+				Usage: "Replay game recorded with --record from given `FILE`, game options are then taken from the recording",
+			},
+			&cli.BoolFlag{
+				Name:  "headless",
+				Usage: "Play the game without screen as fast as possible and print JSON summary at the end, all players have to be controlled by computer unless --replay is used",
+			},
+			&cli.IntFlag{
+				Name:  "rounds",
+				Usage: "`NUMBER` of rounds played in headless mode",
+				Value: 1,
+			},
+			&cli.StringFlag{
+				Name:  "load",
+				Usage: "Resume game saved with Ctrl+S or from the menu from given `FILE`, game options are then taken from the saved game",
+			},
+			&cli.StringFlag{
+				Name:        "save",
+				Usage:       "Save the game to given `FILE` when Ctrl+S is pressed or when it's chosen from the menu",
+				DefaultText: "file given by --load or " + defaultSavePath,
+			},
+			&cli.BoolFlag{
+				Name:   "no-save",
+				Usage:  "Disable saving of the game, it's used for games started by the SSH server",
+				Hidden: true,
+			},
+			altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+				Name:  "profile",
+				Usage: "Player `SLOT:NAME` using profile with given name, e.g. 1:john, can be used multiple times. Career statistics of the player are saved to the profile after the game",
+			}),
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "serve",
+				Usage:     "Run server allowing players to play the game over SSH",
+				UsageText: "gorched [global options] serve --ssh ADDRESS [--host-key FILE] [--lobby-players NUMBER]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "ssh",
+						Usage:    "Listen for SSH connections on given `ADDRESS` (e.g. :2222)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "host-key",
+						Usage: "Private host key will be loaded from given `FILE`, new key will be generated there if it does not exist",
+						Value: "gorched_host_key",
+					},
+					&cli.IntFlag{
+						Name:  "lobby-players",
+						Usage: "`NUMBER` of players in shared game, it can be from 2 to 8",
+						Value: 2,
+					},
+				},
+				Action: serve,
+			},
+			{
+				Name:      "stats",
+				Usage:     "Print career statistics of player profiles",
+				UsageText: "gorched stats [NAME...]",
+				Action:    stats,
+			},
 		},
 		HideHelpCommand: true,
+		Before:          loadConfig,
 		Action:          run,
 	}
 
@@ -83,10 +247,66 @@ func run(c *cli.Context) error {
 		seed = time.Now().UTC().UnixNano()
 	}
 
+	// validate players count
+	players := c.Int("players")
+	if players < 2 || players > 8 {
+		return fmt.Errorf("Invalid number of players %d, it should be from 2 to 8", players)
+	}
+
+	// parse computer players
+	bots, err := parseBots(c.StringSlice("ai"), players)
+	if err != nil {
+		return err
+	}
+
+	// parse player profiles
+	profiles, err := parseProfiles(c.StringSlice("profile"), players)
+	if err != nil {
+		return err
+	}
+
+	// parse player names and colors, names of profiles win over names
+	names, err := parseNames(c.StringSlice("names"), players)
+	if err != nil {
+		return err
+	}
+	names = mergeNames(names, profiles)
+	colors, err := parseColors(c.StringSlice("colors"), players)
+	if err != nil {
+		return err
+	}
+
+	// parse key bindings
+	bindings, err := parseKeys(c.StringSlice("keys"), players, c.Bool("browser"))
+	if err != nil {
+		return err
+	}
+
+	// validate world tunables
+	tunables, err := parseTunables(c)
+	if err != nil {
+		return err
+	}
+
+	// parse wind mode
+	wind, err := parseWind(c.String("wind"))
+	if err != nil {
+		return err
+	}
+
 	// get screen dimensions from flag otherwise from actual terminal size
 	// TODO: validate some minimal size
 	width := c.Int("width")
 	height := c.Int("height")
+	headless := c.Bool("headless")
+	if headless {
+		if width <= 0 {
+			width = headlessWidth
+		}
+		if height <= 0 {
+			height = headlessHeight
+		}
+	}
 	if width <= 0 || height <= 0 {
 		tw, th, err := terminal.GetSize(int(os.Stdout.Fd()))
 		if err != nil {
@@ -100,18 +320,186 @@ func run(c *cli.Context) error {
 		}
 	}
 
-	// create new game
-	game := gorched.NewGame(gorched.GameOptions{
+	// prepare game options
+	options := gorched.GameOptions{
 		Width:       width,
 		Height:      height,
 		Seed:        seed,
-		PlayerCount: 2,
+		PlayerCount: players,
 		Fps:         c.Int("fps"),
 		ASCIIOnly:   c.Bool("ascii-only"),
 		LowColor:    c.Bool("low-color"),
 		BrowserMode: c.Bool("browser"),
 		Debug:       c.Bool("debug"),
-	})
+		Names:       names,
+		Colors:      colors,
+		Keys:        bindings,
+		Bots:        bots,
+		Wind:        wind,
+		World:       tunables,
+		Interest:    c.Float64("interest"),
+	}
+
+	// spectating is possible only for network game
+	if c.Bool("spectate") && c.String("join") == "" {
+		return errors.New("Flag --spectate can be used only together with --join")
+	}
+
+	// recorded game can be replayed only when all input is coming from the local keyboard
+	for _, flag := range []string{"record", "replay"} {
+		if c.String(flag) == "" {
+			continue
+		}
+		for _, other := range []string{"host", "join", "demo", "record", "replay", "load"} {
+			if other != flag && c.String(other) != "" {
+				return fmt.Errorf("Flag --%s can not be used together with --%s", flag, other)
+			}
+		}
+	}
+
+	// saved game can be resumed only locally or as host of network game
+	if c.String("load") != "" && c.String("join") != "" {
+		return errors.New("Flag --load can not be used together with --join")
+	}
+
+	// profiles are updated only by games played locally
+	if len(profiles) > 0 {
+		for _, other := range []string{"join", "replay"} {
+			if c.String(other) != "" {
+				return fmt.Errorf("Flag --profile can not be used together with --%s", other)
+			}
+		}
+	}
+
+	// headless game can be driven only by computer players or by recording
+	if headless {
+		for _, other := range []string{"host", "join", "demo"} {
+			if c.String(other) != "" {
+				return fmt.Errorf("Flag --headless can not be used together with --%s", other)
+			}
+		}
+		if c.Int("rounds") < 1 {
+			return fmt.Errorf("Invalid number of rounds %d, it should be at least 1", c.Int("rounds"))
+		}
+	}
+
+	// load recorded game if requested, game options are then taken from the recording
+	var recording *gorched.Recording
+	if replayPath := c.String("replay"); replayPath != "" {
+		recording, err = loadRecording(replayPath)
+		if err != nil {
+			return fmt.Errorf("Unable to load recording from file '%s': %w", replayPath, err)
+		}
+		options = restoredOptions(recording.Options, options)
+	}
+
+	// load saved game if requested, game options are then taken from the saved game
+	var saved *gorched.SavedGame
+	if loadPath := c.String("load"); loadPath != "" {
+		saved, err = loadGame(loadPath)
+		if err != nil {
+			return fmt.Errorf("Unable to load saved game from file '%s': %w", loadPath, err)
+		}
+		// key bindings are not part of the game, keys given to this game are used
+		saved.Options.Keys = options.Keys
+		options = restoredOptions(saved.Options, options)
+		for pi := range profiles {
+			if pi >= options.PlayerCount {
+				return fmt.Errorf("Invalid player slot '%d' for profile, saved game has only %d players", pi+1, options.PlayerCount)
+			}
+		}
+		if len(profiles) > 0 {
+			options.Names = mergeNames(options.Names, profiles)
+		}
+	}
+
+	// headless game can be driven only by computer players or by recording
+	if headless && recording == nil && len(options.Bots) < options.PlayerCount {
+		return errors.New("All players have to be controlled by computer in headless mode, use --ai for each player or --replay")
+	}
+
+	// join network game if requested, world options are then taken from the host
+	var client *network.Client
+	if address := c.String("join"); address != "" {
+		client, err = network.Join(address, c.Bool("spectate"))
+		if err != nil {
+			return fmt.Errorf("Unable to join game on '%s': %w", address, err)
+		}
+		defer client.Close()
+		options = client.Options(options)
+	}
+
+	// when hosting network game all human players except the first one are remote
+	hostAddress := c.String("host")
+	if hostAddress != "" {
+		for pi := 1; pi < options.PlayerCount; pi++ {
+			if _, ok := options.Bots[pi]; !ok {
+				options.Remotes = append(options.Remotes, pi)
+			}
+		}
+	}
+
+	// create new game
+	game := gorched.NewGame(options)
+
+	// resume saved game, names of players with profiles are kept
+	if saved != nil {
+		game.Restore(saved.Snapshot)
+		for pi, name := range profiles {
+			game.Players()[pi].Name = name
+		}
+	}
+
+	// set the file where the game will be saved
+	savePath := c.String("save")
+	if savePath == "" {
+		savePath = c.String("load")
+	}
+	if savePath == "" {
+		savePath = defaultSavePath
+	}
+	if c.Bool("no-save") {
+		savePath = ""
+	}
+	game.SetSavePath(savePath)
+
+	// start network synchronization
+	if client != nil {
+		client.Attach(game)
+		game.Engine().Screen().AddEntity(client)
+	}
+	if hostAddress != "" {
+		host, err := network.Listen(game, hostAddress)
+		if err != nil {
+			return fmt.Errorf("Unable to host game on '%s': %w", hostAddress, err)
+		}
+		defer host.Close()
+		game.Engine().Screen().AddEntity(host)
+	}
+
+	// start recording if requested
+	if recordPath := c.String("record"); recordPath != "" {
+		file, err := os.Create(recordPath)
+		if err != nil {
+			return fmt.Errorf("Unable to create recording file '%s': %w", recordPath, err)
+		}
+		defer file.Close()
+		recorder, err := gorched.NewRecorder(game, file)
+		if err != nil {
+			return fmt.Errorf("Unable to write recording to file '%s': %w", recordPath, err)
+		}
+		defer func() {
+			if err := recorder.Err(); err != nil {
+				fmt.Fprintf(os.Stderr, "Recording to file '%s' is not complete: %s\n", recordPath, err)
+			}
+		}()
+		game.Controls().Record(recorder)
+	}
+
+	// replay recorded game if requested
+	if recording != nil {
+		game.Controls().Replay(recording.Events)
+	}
 
 	// load demo if requested
 	demoPath := c.String("demo")
@@ -123,9 +511,39 @@ func run(c *cli.Context) error {
 		game.Engine().Screen().AddEntity(demo)
 	}
 
+	// track career statistics of players with profiles
+	var store *profile.Store
+	if len(profiles) > 0 {
+		store, err = loadProfiles()
+		if err != nil {
+			return err
+		}
+		tracked := map[*core.Player]*profile.Profile{}
+		for pi, name := range profiles {
+			tracked[game.Players()[pi]] = store.Get(name)
+		}
+		game.Events().Subscribe(profile.NewTracker(tracked).Handle)
+	}
+
+	// play without screen and print summary if requested
+	if headless {
+		summary := game.RunHeadless(c.Int("rounds"))
+		if err := saveProfiles(store); err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	}
+
 	// start game
 	game.Start()
 
+	// save profiles before anything else to do not lose them
+	if err := saveProfiles(store); err != nil {
+		return err
+	}
+
 	// greet player at the end
 	fmt.Println("Thank you for playing GOrched !")
 	fmt.Printf("Your initial seed was: %d\n", game.InitialSeed())
@@ -134,3 +552,341 @@ func run(c *cli.Context) error {
 	// successful finish
 	return nil
 }
+
+func serve(c *cli.Context) error {
+	// validate lobby players count
+	players := c.Int("lobby-players")
+	if players < 2 || players > 8 {
+		return fmt.Errorf("Invalid number of lobby players %d, it should be from 2 to 8", players)
+	}
+
+	// pass global graphic options to each started game
+	// visitors are not authenticated so they can not write any files to the server
+	// and games do not use the server's configuration which can contain e.g. names or profiles of the operator
+	args := []string{"--fps", strconv.Itoa(c.Int("fps")), "--no-save", "--no-config"}
+	if c.Bool("ascii-only") {
+		args = append(args, "--ascii-only")
+	}
+	if c.Bool("low-color") {
+		args = append(args, "--low-color")
+	}
+
+	// start server
+	s, err := server.NewServer(server.Options{
+		Address:      c.String("ssh"),
+		HostKeyPath:  c.String("host-key"),
+		LobbyPlayers: players,
+		GameArgs:     args,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Listening for SSH connections on %s\n", c.String("ssh"))
+	return s.ListenAndServe()
+}
+
+func stats(c *cli.Context) error {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+
+	// print only requested profiles or all of them
+	selected := profiles.Profiles()
+	if c.Args().Present() {
+		selected = nil
+		for _, name := range c.Args().Slice() {
+			p := profiles.Find(name)
+			if p == nil {
+				return fmt.Errorf("Unknown profile '%s'", name)
+			}
+			selected = append(selected, p)
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Printf("There are no profiles in '%s' yet, use --profile flag to create them\n", profiles.Path())
+		return nil
+	}
+
+	// print table with one row for each profile
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tRATING\tGAMES\tROUNDS\tWINS\tLOSSES\tKILLS\tDEATHS\tSUICIDES\tDAMAGE\tACCURACY\tFAVOURITE WEAPON")
+	for _, p := range selected {
+		fmt.Fprintf(
+			w,
+			"%s\t%.0f\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%.1f%%\t%s\n",
+			p.Name, p.Rating, p.Games, p.Rounds, p.Wins, p.Losses,
+			p.Stats.Kills, p.Stats.Deaths, p.Stats.Suicides, p.Stats.Damage,
+			p.Accuracy(), p.FavouriteWeapon(),
+		)
+	}
+	return w.Flush()
+}
+
+// loadProfiles loads player profiles from the default location
+func loadProfiles() (*profile.Store, error) {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to find directory for profiles: %w", err)
+	}
+	profiles, err := profile.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load profiles from file '%s': %w", path, err)
+	}
+	return profiles, nil
+}
+
+// saveProfiles saves given player profiles, it does nothing if profiles are nil
+func saveProfiles(profiles *profile.Store) error {
+	if profiles == nil {
+		return nil
+	}
+	if err := profiles.Save(); err != nil {
+		return fmt.Errorf("Unable to save profiles to file '%s': %w", profiles.Path(), err)
+	}
+	return nil
+}
+
+// loadConfig sets default flag values from the configuration file.
+// File format is chosen by the file extension, TOML is used for all other extensions than .yaml and .yml.
+// Default configuration file is optional, file given by --config flag has to exist.
+// Nothing is loaded when --no-config flag is given.
+func loadConfig(c *cli.Context) error {
+	if c.Bool("no-config") {
+		return nil
+	}
+	path := c.String("config")
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "gorched", "config.toml")
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+	} else if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("Unable to load configuration from file '%s': %w", path, err)
+	}
+	var source altsrc.InputSourceContext
+	var err error
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		source, err = altsrc.NewYamlSourceFromFile(path)
+	default:
+		source, err = altsrc.NewTomlSourceFromFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to load configuration from file '%s': %w", path, err)
+	}
+	if err := altsrc.ApplyInputSourceValues(c, source, c.App.Flags); err != nil {
+		return fmt.Errorf("Invalid configuration in file '%s': %w", path, err)
+	}
+	return nil
+}
+
+// loadRecording loads recorded game from file on given path
+func loadRecording(path string) (*gorched.Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return gorched.LoadRecording(file)
+}
+
+// loadGame loads saved game from file on given path
+func loadGame(path string) (*gorched.SavedGame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return gorched.LoadGame(file)
+}
+
+// mergeNames returns names of players from both given maps, names from the second map win
+func mergeNames(names, other map[int]string) map[int]string {
+	merged := map[int]string{}
+	for pi, name := range names {
+		merged[pi] = name
+	}
+	for pi, name := range other {
+		merged[pi] = name
+	}
+	return merged
+}
+
+// restoredOptions returns options of recorded or saved game.
+// Only options affecting how the game looks like are taken from actual options.
+// Network options are not restored as they depend on how this game is run, they are set again later if needed.
+func restoredOptions(recorded, actual gorched.GameOptions) gorched.GameOptions {
+	recorded.Fps = actual.Fps
+	recorded.ASCIIOnly = actual.ASCIIOnly
+	recorded.LowColor = actual.LowColor
+	recorded.BrowserMode = actual.BrowserMode
+	recorded.Debug = actual.Debug
+	recorded.Remotes = nil
+	recorded.Client = false
+	recorded.Spectator = false
+	return recorded
+}
+
+// parseWind parses value of --wind flag
+func parseWind(value string) (entities.WindMode, error) {
+	switch value {
+	case "none":
+		return entities.NoWind, nil
+	case "constant":
+		return entities.ConstantWind, nil
+	case "changing":
+		return entities.ChangingWind, nil
+	}
+	return 0, fmt.Errorf("Invalid wind mode '%s', it should be one of: none, constant, changing", value)
+}
+
+// parseNames parses values of --names flag.
+// It returns names by player indexes, empty names are skipped.
+func parseNames(values []string, players int) (map[int]string, error) {
+	if len(values) > players {
+		return nil, fmt.Errorf("Too many player names, there are only %d players", players)
+	}
+	names := map[int]string{}
+	for pi, name := range values {
+		if name = strings.TrimSpace(name); name != "" {
+			names[pi] = name
+		}
+	}
+	return names, nil
+}
+
+// parseColors parses values of --colors flag.
+// It returns tank colors by player indexes, empty colors are skipped.
+func parseColors(values []string, players int) (map[int]tl.Attr, error) {
+	if len(values) > players {
+		return nil, fmt.Errorf("Too many player colors, there are only %d players", players)
+	}
+	colors := map[int]tl.Attr{}
+	for pi, name := range values {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		color, err := entities.ColorByName(name)
+		if err != nil {
+			return nil, err
+		}
+		colors[pi] = color
+	}
+	return colors, nil
+}
+
+// parseKeys parses values of --keys flag.
+// Keys given for some command replace its default keys, more keys can be bound to one command by more values.
+func parseKeys(values []string, players int, browserMode bool) (keys.Bindings, error) {
+	bindings := keys.NewBindings(browserMode)
+	bound := map[int]map[keys.Command]bool{}
+	for _, value := range values {
+		player, command, key, err := keys.ParseBinding(value)
+		if err != nil {
+			return bindings, err
+		}
+		if player >= players {
+			return bindings, fmt.Errorf("Invalid player slot '%d' in key binding '%s', there are only %d players", player+1, value, players)
+		}
+		m := bindings.Global
+		if player >= 0 {
+			m = bindings.Player(player)
+		}
+		if bound[player] == nil {
+			bound[player] = map[keys.Command]bool{}
+		}
+		if bound[player][command] {
+			m.Add(command, key)
+		} else {
+			m.Bind(command, key)
+			bound[player][command] = true
+		}
+	}
+	return bindings, nil
+}
+
+// commandNames returns names of all commands which can be bound to the keys
+func commandNames() []string {
+	names := []string{}
+	for _, info := range keys.Commands {
+		names = append(names, string(info.Command))
+	}
+	return names
+}
+
+// parseTunables returns world tunables given by flags
+func parseTunables(c *cli.Context) (entities.Tunables, error) {
+	t := entities.Tunables{
+		Gravity:     c.Float64("gravity"),
+		Roughness:   c.Float64("roughness"),
+		TreeDensity: c.Float64("tree-density"),
+		TreeSize:    c.Int("tree-size"),
+	}
+	if t.Gravity <= 0 {
+		return t, fmt.Errorf("Invalid gravity %g, it should be greater than 0", t.Gravity)
+	}
+	if t.Roughness <= 0 {
+		return t, fmt.Errorf("Invalid roughness %g, it should be greater than 0", t.Roughness)
+	}
+	if t.TreeDensity < 0 || t.TreeDensity > 1 {
+		return t, fmt.Errorf("Invalid tree density %g, it should be from 0 to 1", t.TreeDensity)
+	}
+	if t.TreeSize < 1 {
+		return t, fmt.Errorf("Invalid tree size %d, it should be at least 1", t.TreeSize)
+	}
+	return t, nil
+}
+
+// parseProfiles parses values of --profile flag.
+// Each value should contain player slot followed by colon and profile name.
+// It returns profile names by player indexes.
+func parseProfiles(values []string, players int) (map[int]string, error) {
+	names := map[int]string{}
+	used := map[string]bool{}
+	for _, v := range values {
+		parts := strings.SplitN(v, ":", 2)
+		slot, err := strconv.Atoi(parts[0])
+		if err != nil || slot < 1 || slot > players {
+			return nil, fmt.Errorf("Invalid player slot '%s' for profile, it should be from 1 to %d", parts[0], players)
+		}
+		if len(parts) < 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Missing profile name for player slot %d, use SLOT:NAME", slot)
+		}
+		name := strings.TrimSpace(parts[1])
+		if used[name] {
+			return nil, fmt.Errorf("Profile '%s' can be used only for one player", name)
+		}
+		used[name] = true
+		names[slot-1] = name
+	}
+	return names, nil
+}
+
+// parseBots parses values of --ai flag.
+// Each value should contain player slot optionally followed by colon and AI profile name.
+// It returns AI profile names by player indexes.
+func parseBots(values []string, players int) (map[int]string, error) {
+	bots := map[int]string{}
+	for _, v := range values {
+		parts := strings.SplitN(v, ":", 2)
+		slot, err := strconv.Atoi(parts[0])
+		if err != nil || slot < 1 || slot > players {
+			return nil, fmt.Errorf("Invalid player slot '%s' for computer player, it should be from 1 to %d", parts[0], players)
+		}
+		profile := ai.DefaultProfile
+		if len(parts) > 1 {
+			p, err := ai.ProfileByName(parts[1])
+			if err != nil {
+				return nil, err
+			}
+			profile = p.Name
+		}
+		bots[slot-1] = profile
+	}
+	return bots, nil
+}
@@ -1,85 +1,325 @@
 package gorched
 
 import (
+	"fmt"
+	"math"
+
 	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/gmath"
+	"github.com/zladovan/gorched/hud"
+	"github.com/zladovan/gorched/keys"
 )
 
 // Controls holds data and logic for controlling game world.
 type Controls struct {
 	// reference to game
 	game *Game
+	// Handler if set will receive all tank actions instead of applying them directly.
+	// It can be used to process actions in other way e.g. to send them over the network.
+	// Handler can call Apply to apply action to the active tank.
+	Handler func(a Action, aim Aim)
+	// recorder if set will record all received input events
+	recorder *Recorder
+	// replay holds recorded events which were not replayed yet, input from keyboard is ignored until all of them are replayed
+	replay []RecordedEvent
+	// aiming is true while left mouse button is pressed to aim and load the shot
+	aiming bool
 }
 
-// Tick handles all key events
+// Action is an action which can be done with active tank
+type Action uint8
+
+const (
+	// MoveUp increases cannon's angle
+	MoveUp Action = iota
+	// MoveDown decreases cannon's angle
+	MoveDown
+	// Shoot starts loading or shoots if already loading
+	Shoot
+	// NextWeapon selects next weapon from the inventory
+	NextWeapon
+	// PreviousWeapon selects previous weapon from the inventory
+	PreviousWeapon
+	// Fire shoots right away with the angle and power given by Aim
+	Fire
+	// Rotate changes cannon's angle to the angle given by Aim
+	Rotate
+	// DriveLeft moves the tank to the left
+	DriveLeft
+	// DriveRight moves the tank to the right
+	DriveRight
+)
+
+// Aim holds exact cannon's angle and shooting power, it's used only by Fire and Rotate actions
+type Aim struct {
+	// Angle is cannon's angle from 0 to 180
+	Angle int
+	// Power is shooting power from 0 to the maximal power of the player
+	Power int
+}
+
+// commandActions holds tank actions by the commands which are bound to the keys
+var commandActions = map[keys.Command]Action{
+	keys.AimLeft:        MoveUp,
+	keys.AimRight:       MoveDown,
+	keys.Shoot:          Shoot,
+	keys.NextWeapon:     NextWeapon,
+	keys.PreviousWeapon: PreviousWeapon,
+	keys.DriveLeft:      DriveLeft,
+	keys.DriveRight:     DriveRight,
+}
+
+// Tick handles all key events.
+// Events are ignored when recorded game is replayed.
 func (c *Controls) Tick(e tl.Event) {
+	if len(c.replay) > 0 {
+		return
+	}
+
 	// TODO: show some message box after resize about restart round is needed to be applied
 	// on resize update game options to be applied on round restart or on next round
 	if e.Type == tl.EventResize {
 		w, h := c.game.engine.Screen().Size()
-		c.game.options.Width = w
-		c.game.options.Height = h
+		if c.recorder != nil {
+			c.recorder.record(RecordedEvent{Type: e.Type, Width: w, Height: h})
+		}
+		c.resize(w, h)
+		return
+	}
+
+	if c.recorder != nil {
+		c.recorder.record(newRecordedEvent(e))
+	}
+	c.handle(e)
+}
+
+// Record starts recording of all input events with given recorder
+func (c *Controls) Record(r *Recorder) {
+	c.recorder = r
+}
+
+// Replay starts replaying of given recorded events.
+// Each event is handled right before the simulation step with the same number as it was recorded.
+// Input from keyboard is ignored until all events are replayed.
+func (c *Controls) Replay(events []RecordedEvent) {
+	c.replay = events
+}
+
+// IsReplaying returns true if there are some recorded events which were not replayed yet
+func (c *Controls) IsReplaying() bool {
+	return len(c.replay) > 0
+}
+
+// update handles all recorded events which should be replayed before actual simulation step
+func (c *Controls) update() {
+	for len(c.replay) > 0 && c.replay[0].Tick <= c.game.Ticks() {
+		e := c.replay[0]
+		c.replay = c.replay[1:]
+		if e.Type == tl.EventResize {
+			c.resize(e.Width, e.Height)
+		} else {
+			c.handle(e.Event())
+		}
+	}
+}
+
+// resize updates game options to be applied on round restart or on next round
+func (c *Controls) resize(w, h int) {
+	c.game.Hud().Resize(w, h)
+	if c.game.options.Client {
+		return
 	}
+	c.game.options.Width = w
+	c.game.options.Height = h
+}
 
+// handle processes one input event
+func (c *Controls) handle(e tl.Event) {
 	// when message box is shown it is in control
 	if c.game.Hud().IsFormShown() {
+		c.game.Hud().Input(e)
 		return
 	}
 
-	// otherwise handle in-game controls
-	switch e.Key {
-	case tl.KeyArrowLeft:
-		c.MoveUp()
-	case tl.KeyArrowRight:
-		c.MoveDown()
-	case tl.KeySpace:
-		c.Shoot()
-	case tl.KeyCtrlR:
-		c.RestartRound()
-	case tl.KeyCtrlN:
-		c.NextRound()
+	// spectators can only watch the game
+	if c.game.options.Spectator {
+		return
+	}
+
+	// mouse is used only for aiming and shooting
+	if e.Type == tl.EventMouse {
+		c.mouse(e)
+		return
+	}
+
+	// tank can be controlled from keyboard only by local human player, his own keys are used then
+	player := -1
+	if c.isHumanOnTurn() {
+		player = c.game.round.ActivePlayerIndex()
 	}
-	switch e.Ch {
-	case 'h':
+	command, ok := c.game.options.Keys.Command(player, keys.FromEvent(e))
+	if !ok {
+		return
+	}
+	if action, ok := commandActions[command]; ok {
+		if player >= 0 {
+			c.act(action)
+		}
+		return
+	}
+
+	// forms can be shown in all cases
+	switch command {
+	case keys.Help:
 		c.ShowInfo()
-	case 's':
+	case keys.Score:
 		c.ShowScore()
-	case 'a':
+	case keys.Attributes:
 		c.ShowAttributes()
-	}
-	// for the browser mode we cannot use ctrl+n and ctr+r as we would leave the window
-	if c.game.options.BrowserMode {
-		switch e.Ch {
-		case 'n':
-			c.NextRound()
-		case 'r':
-			c.RestartRound()
+	case keys.Menu:
+		c.ShowMenu()
+	case keys.Settings:
+		c.ShowSettings()
+	case keys.Aim:
+		if player >= 0 {
+			c.ShowAim()
 		}
 	}
+
+	// rounds are switched only by host when game is client of some remote game
+	if c.game.options.Client {
+		return
+	}
+
+	// otherwise handle round controls
+	switch command {
+	case keys.RestartRound:
+		c.RestartRound()
+	case keys.NextRound:
+		c.NextRound()
+	case keys.Save:
+		c.SaveGame()
+	}
 }
 
 // Draw does nothing now
 func (c *Controls) Draw(s *tl.Screen) {}
 
+// mouse aims with the tank of local human player on turn towards the mouse pointer.
+// Pressing the left button starts loading, moving with the button pressed changes the angle and releasing the button shoots.
+func (c *Controls) mouse(e tl.Event) {
+	if !c.isHumanOnTurn() {
+		c.aiming = false
+		return
+	}
+	tank := c.game.round.ActiveTank()
+	switch e.Key {
+	case tl.MouseLeft:
+		if angle := angleTowards(tank.Center(), e.MouseX, e.MouseY); angle != tank.Angle() {
+			c.actWith(Rotate, Aim{Angle: angle})
+		}
+		if !c.aiming && tank.IsIdle() {
+			c.Shoot()
+		}
+		c.aiming = true
+	case tl.MouseRelease:
+		if c.aiming && tank.IsLoading() {
+			c.Shoot()
+		}
+		c.aiming = false
+	}
+}
+
+// angleTowards returns cannon's angle pointing from given center of the tank to the given screen position.
+// Positions below the tank are pointed by the horizontal angle.
+func angleTowards(center gmath.Vector2f, x, y int) int {
+	dx := float64(x) - center.X
+	dy := center.Y - float64(y)
+	if dy < 0 {
+		dy = 0
+	}
+	return int(math.Round(math.Atan2(dy, dx) * 180 / math.Pi))
+}
+
+// isHumanOnTurn returns true if the player on turn is controlled by local human player
+func (c *Controls) isHumanOnTurn() bool {
+	return c.game.round.IsPlayerOnTurn() && c.game.round.ActiveTank().Player().Type == core.Human
+}
+
 // Following methods can be also used from outside as a support for other external controller
 
 // MoveUp increase cannon's angle of active tank
 func (c *Controls) MoveUp() {
-	if c.game.round.IsPlayerOnTurn() {
-		c.game.round.ActiveTank().MoveUp()
-	}
+	c.act(MoveUp)
 }
 
 // MoveDown decreases cannon's angle of active tank
 func (c *Controls) MoveDown() {
-	if c.game.round.IsPlayerOnTurn() {
-		c.game.round.ActiveTank().MoveDown()
-	}
+	c.act(MoveDown)
 }
 
 // Shoot will start loading or shoot with active tank if it's already loading
 func (c *Controls) Shoot() {
-	if c.game.round.IsPlayerOnTurn() {
-		c.game.round.ActiveTank().Shoot()
+	c.act(Shoot)
+}
+
+// NextWeapon selects next weapon of active tank
+func (c *Controls) NextWeapon() {
+	c.act(NextWeapon)
+}
+
+// PreviousWeapon selects previous weapon of active tank
+func (c *Controls) PreviousWeapon() {
+	c.act(PreviousWeapon)
+}
+
+// FireWith shoots right away with active tank using given cannon's angle and shooting power
+func (c *Controls) FireWith(angle, power int) {
+	c.actWith(Fire, Aim{Angle: angle, Power: power})
+}
+
+// act passes given action to the Handler if set, otherwise it applies action directly
+func (c *Controls) act(a Action) {
+	c.actWith(a, Aim{})
+}
+
+// actWith passes given action with given aim to the Handler if set, otherwise it applies action directly
+func (c *Controls) actWith(a Action, aim Aim) {
+	if c.Handler != nil {
+		c.Handler(a, aim)
+		return
+	}
+	c.Apply(a, aim)
+}
+
+// Apply applies given action to the active tank.
+// Given aim is used only by Fire action.
+// Action is ignored if no player is on turn.
+func (c *Controls) Apply(a Action, aim Aim) {
+	if !c.game.round.IsPlayerOnTurn() {
+		return
+	}
+	tank := c.game.round.ActiveTank()
+	switch a {
+	case MoveUp:
+		tank.MoveUp()
+	case MoveDown:
+		tank.MoveDown()
+	case Shoot:
+		tank.Shoot()
+	case NextWeapon:
+		tank.NextWeapon()
+	case PreviousWeapon:
+		tank.PreviousWeapon()
+	case Fire:
+		tank.ShootWith(aim.Angle, aim.Power)
+	case Rotate:
+		tank.RotateTo(aim.Angle)
+	case DriveLeft:
+		tank.Drive(c.game.round.world, -1)
+	case DriveRight:
+		tank.Drive(c.game.round.world, 1)
 	}
 }
 
@@ -98,6 +338,40 @@ func (c *Controls) RestartRound() {
 	c.game.round.Restart()
 }
 
+// SaveGame saves the game to the file and shows message with the result
+func (c *Controls) SaveGame() {
+	if c.game.SavePath() == "" {
+		c.game.Hud().ShowMessage("Saving is disabled")
+		return
+	}
+	if err := c.game.SaveFile(); err != nil {
+		c.game.Hud().ShowMessage(fmt.Sprintf("Unable to save the game: %s", err))
+		return
+	}
+	c.game.Hud().ShowMessage(fmt.Sprintf("Game was saved to '%s'", c.game.SavePath()))
+}
+
+// ShowMenu shows menu with game actions.
+// Round and saving actions are not available when game is client of some remote game.
+// Saving is not available also when there is no file for saving the game.
+func (c *Controls) ShowMenu() {
+	items := []hud.MenuItem{{Title: "Continue", Key: 'C', Action: func() {}}}
+	if !c.game.options.Client && c.game.SavePath() != "" {
+		items = append(items, hud.MenuItem{Title: "Save game", Key: 'S', Action: c.SaveGame})
+	}
+	if !c.game.options.Client {
+		items = append(items,
+			hud.MenuItem{Title: "Restart round", Key: 'R', Action: c.RestartRound},
+			hud.MenuItem{Title: "Next round", Key: 'N', Action: c.NextRound},
+		)
+	}
+	items = append(items,
+		hud.MenuItem{Title: "Settings", Key: 't', Action: c.ShowSettings},
+		hud.MenuItem{Title: "Help", Key: 'H', Action: c.ShowInfo},
+	)
+	c.game.Hud().ShowMenu(items)
+}
+
 // ShowInfo shows main game information
 func (c *Controls) ShowInfo() {
 	c.game.Hud().ShowInfo()
@@ -113,6 +387,24 @@ func (c *Controls) ShowAttributes() {
 	c.game.hud.ShowAttributes(true)
 }
 
+// ShowAim shows form for typing exact angle and power of the next shot.
+// It's shown only when local human player is on turn and his tank is not shooting yet.
+func (c *Controls) ShowAim() {
+	if !c.isHumanOnTurn() {
+		return
+	}
+	tank := c.game.round.ActiveTank()
+	if !tank.IsIdle() && !tank.IsLoading() {
+		return
+	}
+	c.game.Hud().ShowAim(tank.Angle(), tank.Power(), tank.MaxPower(), c.FireWith)
+}
+
+// ShowSettings shows form for changing key bindings
+func (c *Controls) ShowSettings() {
+	c.game.Hud().ShowSettings()
+}
+
 // MoveFocus moves focus to next component on currently opened form.
 // If no form is opened ignore it.
 func (c *Controls) MoveFocus() {
@@ -0,0 +1,135 @@
+package core
+
+import "github.com/zladovan/gorched/gmath"
+
+// Event is something what happened in the game model.
+// All events are values of the types declared in this file, use type switch to recognize them.
+type Event interface{}
+
+// Handler is function reacting on the published event
+type Handler func(e Event)
+
+// Events is the bus of all events happened in the game.
+// Model only pushes events to the queue when they happen.
+// They are passed to all subscribed handlers when Publish is called which is once per simulation step.
+// It allows to react on the game events (e.g. collect stats, play sounds, synchronize network game) without changing the model or the entities.
+type Events struct {
+	// queue holds events which were not published yet in the order in which they happened
+	queue []Event
+	// subscriptions holds all active subscriptions in the order in which they were created
+	subscriptions []*subscription
+}
+
+// subscription binds handler to the events
+type subscription struct {
+	// handler is called for each published event
+	handler Handler
+	// cancelled is true when handler should not be called anymore
+	cancelled bool
+}
+
+// Push adds given event to the queue
+func (e *Events) Push(event Event) {
+	e.queue = append(e.queue, event)
+}
+
+// Subscribe registers given handler which will be called for each published event.
+// Handlers are called in the order in which they were subscribed.
+// Returned function cancels the subscription.
+func (e *Events) Subscribe(handler Handler) func() {
+	s := &subscription{handler: handler}
+	e.subscriptions = append(e.subscriptions, s)
+	return func() {
+		s.cancelled = true
+		for i, o := range e.subscriptions {
+			if o == s {
+				e.subscriptions = append(e.subscriptions[:i], e.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish passes all queued events to all subscribed handlers and clears the queue.
+// Events pushed by handlers during publishing are published too.
+func (e *Events) Publish() {
+	for len(e.queue) > 0 {
+		event := e.queue[0]
+		e.queue = e.queue[1:]
+		subscriptions := make([]*subscription, len(e.subscriptions))
+		copy(subscriptions, e.subscriptions)
+		for _, s := range subscriptions {
+			if !s.cancelled {
+				s.handler(event)
+			}
+		}
+	}
+}
+
+// RoundStarted happens when the first player is going to be on turn in the new or restarted round
+type RoundStarted struct {
+	// Round is the started round
+	Round *Round
+}
+
+// TurnStarted happens when some tank is on turn
+type TurnStarted struct {
+	// Tank is the tank on turn
+	Tank *Tank
+}
+
+// ShotFired happens when tank fires it's weapon
+type ShotFired struct {
+	// Tank is the tank which fired
+	Tank *Tank
+	// Weapon is the name of the fired weapon
+	Weapon string
+}
+
+// ProjectileExploded happens when projectile hits something and explodes
+type ProjectileExploded struct {
+	// Projectile is the exploded projectile
+	Projectile *Projectile
+	// Position is the place of the explosion
+	Position gmath.Vector2i
+}
+
+// TankDamaged happens when tank takes some damage
+type TankDamaged struct {
+	// Tank is the damaged tank
+	Tank *Tank
+	// Enemy is the tank which caused the damage, it can be nil
+	Enemy *Tank
+	// Amount is number of hit points really taken
+	Amount int
+}
+
+// ShieldDamaged happens when tank's shield absorbs some damage
+type ShieldDamaged struct {
+	// Tank is the tank protected by the shield
+	Tank *Tank
+	// Amount is the damage absorbed by the shield
+	Amount int
+}
+
+// TankDied happens when tank lost all it's health
+type TankDied struct {
+	// Tank is the dead tank
+	Tank *Tank
+	// Enemy is the tank which killed it, it can be nil or the same tank in case of suicide
+	Enemy *Tank
+}
+
+// RoundFinished happens when there is only one or zero tanks alive in the round
+type RoundFinished struct {
+	// Round is the finished round
+	Round *Round
+}
+
+// GameOver happens when the game is ended
+type GameOver struct {
+	// Players holds all players with their final statistics
+	Players Players
+	// Round is the last played round
+	Round *Round
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventsPublish(t *testing.T) {
+	tests := []struct {
+		name   string
+		pushed []Event
+		// push is event pushed by the first handler when it receives event "push"
+		push   Event
+		cancel bool
+		want   []Event
+	}{
+		{name: "nothing pushed", want: []Event{}},
+		{name: "events in push order", pushed: []Event{1, 2, 3}, want: []Event{1, 2, 3}},
+		{name: "events pushed during publishing", pushed: []Event{"push", 2}, push: 3, want: []Event{"push", 2, 3}},
+		{name: "cancelled subscription", pushed: []Event{1, 2}, cancel: true, want: []Event{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &Events{}
+			got := []Event{}
+			events.Subscribe(func(e Event) {
+				if e == "push" {
+					events.Push(tt.push)
+				}
+			})
+			cancel := events.Subscribe(func(e Event) { got = append(got, e) })
+			if tt.cancel {
+				cancel()
+			}
+			for _, e := range tt.pushed {
+				events.Push(e)
+			}
+
+			events.Publish()
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("published = %v, want %v", got, tt.want)
+			}
+			if len(events.queue) != 0 {
+				t.Errorf("queue = %v, want empty", events.queue)
+			}
+		})
+	}
+}
@@ -1,7 +1,13 @@
 package core
 
-// Game is only holder of the players for now
+// Game is holder of the players and it provides information about the current round
 type Game interface {
 	// Players returns all players in the game
 	Players() Players
+	// Wind returns strength of the wind in the current round, negative values are blowing to the left
+	Wind() int
+	// ActiveTank returns tank which is on turn in the current round
+	ActiveTank() *Tank
+	// Events returns bus of all events happened in the game
+	Events() *Events
 }
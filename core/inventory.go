@@ -0,0 +1,50 @@
+package core
+
+// Unlimited is ammo count of weapons which can be used without limits
+const Unlimited = -1
+
+// Inventory holds ammo counts of player's weapons by the weapon names.
+// Weapons which are not present or which have zero ammo cannot be used.
+type Inventory map[string]int
+
+// Ammo returns how many times weapon with given name can be used.
+// It returns Unlimited for weapons without limits.
+func (i Inventory) Ammo(weapon string) int {
+	return i[weapon]
+}
+
+// Has returns true if weapon with given name can be used at least once
+func (i Inventory) Has(weapon string) bool {
+	return i[weapon] != 0
+}
+
+// Add adds given amount of ammo to the weapon with given name.
+// Nothing is added to the weapons without limits.
+func (i Inventory) Add(weapon string, amount int) {
+	if i[weapon] == Unlimited {
+		return
+	}
+	i[weapon] += amount
+}
+
+// Copy returns new inventory with the same content
+func (i Inventory) Copy() Inventory {
+	c := make(Inventory, len(i))
+	for w, a := range i {
+		c[w] = a
+	}
+	return c
+}
+
+// Take takes one ammo of the weapon with given name.
+// It returns false if there is no ammo left.
+func (i Inventory) Take(weapon string) bool {
+	switch i[weapon] {
+	case 0:
+		return false
+	case Unlimited:
+		return true
+	}
+	i[weapon]--
+	return true
+}
@@ -0,0 +1,53 @@
+package core
+
+import "math"
+
+const (
+	// KillReward is amount of money earned for each killed enemy
+	KillReward = 50
+	// DamageReward is amount of money earned for each hit point taken from enemies
+	DamageReward = 1
+)
+
+// Earnings returns amount of money earned for given stats
+func (s Stats) Earnings() int {
+	return s.Kills*KillReward + s.Damage*DamageReward
+}
+
+// AddInterest adds interest to the player's money.
+// Rate is given in percents.
+func (p *Player) AddInterest(rate float64) {
+	p.Money += int(math.Floor(float64(p.Money) * rate / 100))
+}
+
+// Goods is something what can be bought in the shop
+type Goods struct {
+	// Name is name of the weapon or item which is added to the player's inventory
+	Name string
+	// Price is amount of money needed to buy goods
+	Price int
+	// Amount is how many pieces of weapon or item are bought at once
+	Amount int
+}
+
+// Buy buys given goods and adds them to the player's inventory.
+// It returns false if player has not enough money.
+func (p *Player) Buy(g Goods) bool {
+	if p.Money < g.Price {
+		return false
+	}
+	p.Money -= g.Price
+	p.Inventory.Add(g.Name, g.Amount)
+	return true
+}
+
+// Sell returns given goods from the player's inventory back to the shop for the same price.
+// It returns false if player has not enough pieces of goods.
+func (p *Player) Sell(g Goods) bool {
+	if p.Inventory.Ammo(g.Name) < g.Amount {
+		return false
+	}
+	p.Money += g.Price
+	p.Inventory.Add(g.Name, -g.Amount)
+	return true
+}
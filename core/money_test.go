@@ -0,0 +1,89 @@
+package core
+
+import "testing"
+
+func TestStatsEarnings(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats Stats
+		want  int
+	}{
+		{name: "nothing earned", stats: Stats{Deaths: 1, Suicides: 1}, want: 0},
+		{name: "damage", stats: Stats{Damage: 30}, want: 30 * DamageReward},
+		{name: "kills and damage", stats: Stats{Kills: 2, Damage: 100}, want: 2*KillReward + 100*DamageReward},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.Earnings(); got != tt.want {
+				t.Errorf("Earnings() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayerAddInterest(t *testing.T) {
+	tests := []struct {
+		name  string
+		money int
+		rate  float64
+		want  int
+	}{
+		{name: "no interest", money: 100, rate: 0, want: 100},
+		{name: "no money", money: 0, rate: 10, want: 0},
+		{name: "interest", money: 100, rate: 10, want: 110},
+		{name: "interest is rounded down", money: 99, rate: 10, want: 108},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlayer("Player")
+			p.Money = tt.money
+			p.AddInterest(tt.rate)
+			if p.Money != tt.want {
+				t.Errorf("Money = %d, want %d", p.Money, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayerBuyAndSell(t *testing.T) {
+	goods := Goods{Name: "Missile", Price: 30, Amount: 2}
+	tests := []struct {
+		name      string
+		money     int
+		ammo      int
+		sell      bool
+		wantOk    bool
+		wantMoney int
+		wantAmmo  int
+	}{
+		{name: "buy", money: 50, wantOk: true, wantMoney: 20, wantAmmo: 2},
+		{name: "buy for all money", money: 30, ammo: 1, wantOk: true, wantMoney: 0, wantAmmo: 3},
+		{name: "not enough money", money: 29, wantOk: false, wantMoney: 29, wantAmmo: 0},
+		{name: "sell", sell: true, money: 10, ammo: 3, wantOk: true, wantMoney: 40, wantAmmo: 1},
+		{name: "not enough ammo to sell", sell: true, money: 10, ammo: 1, wantOk: false, wantMoney: 10, wantAmmo: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlayer("Player")
+			p.Money = tt.money
+			p.Inventory.Add(goods.Name, tt.ammo)
+
+			var ok bool
+			if tt.sell {
+				ok = p.Sell(goods)
+			} else {
+				ok = p.Buy(goods)
+			}
+
+			if ok != tt.wantOk {
+				t.Errorf("ok = %t, want %t", ok, tt.wantOk)
+			}
+			if p.Money != tt.wantMoney {
+				t.Errorf("Money = %d, want %d", p.Money, tt.wantMoney)
+			}
+			if got := p.Inventory.Ammo(goods.Name); got != tt.wantAmmo {
+				t.Errorf("Ammo() = %d, want %d", got, tt.wantAmmo)
+			}
+		})
+	}
+}
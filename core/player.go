@@ -8,8 +8,32 @@ type Player struct {
 	Stats Stats
 	// Attributes are the player's attributes
 	Attributes Attributes
+	// Type identifies who controls this player
+	Type PlayerType
+	// Difficulty is name of the AI profile used when player is controlled by computer
+	Difficulty string
+	// LastShot holds result of the last shot of this player, it's nil if player did not shoot yet
+	LastShot *Shot
+	// Inventory holds ammo of player's weapons
+	Inventory Inventory
+	// Weapon is name of the selected weapon which will be used for the next shot
+	Weapon string
+	// Money is amount of money which player can spend in the shop
+	Money int
 }
 
+// PlayerType identifies who controls the player
+type PlayerType uint8
+
+const (
+	// Human player is controlled by keyboard
+	Human PlayerType = iota
+	// Computer player is controlled by bot
+	Computer
+	// Remote player is controlled from another process over the network
+	Remote
+)
+
 // NewPlayer creates player with given name and with default attributes
 func NewPlayer(name string) *Player {
 	return &Player{
@@ -17,31 +41,70 @@ func NewPlayer(name string) *Player {
 		Attributes: Attributes{
 			Attack:  1,
 			Defense: 1,
+			Engine:  1,
 		},
+		Inventory: Inventory{},
 	}
 }
 
+// IsComputer returns true if this player is controlled by computer
+func (p *Player) IsComputer() bool {
+	return p.Type == Computer
+}
+
+// ReportShot should be called when bullet shot by this player finished its flight
+func (p *Player) ReportShot(s Shot) {
+	p.LastShot = &s
+}
+
 // AddStats add to each of player stats values given by s.
 // Use to report statistics after round finish
 func (p *Player) AddStats(s Stats) {
 	p.Stats.Kills += s.Kills
 	p.Stats.Deaths += s.Deaths
 	p.Stats.Suicides += s.Suicides
+	p.Stats.Damage += s.Damage
+}
+
+// Shot holds information about one shot and about the place where it ended
+type Shot struct {
+	// Angle of the cannon used for the shot
+	Angle int
+	// Power used for the shot
+	Power int
+	// X is x coordinate of the point where bullet exploded or left the world
+	X int
+	// Y is y coordinate of the point where bullet exploded or left the world
+	Y int
+	// Lost is true if bullet left the world without explosion
+	Lost bool
 }
 
 // Players is array of multiple players
 type Players []*Player
 
+// Humans returns players controlled by people, it includes remote players
+func (p Players) Humans() Players {
+	humans := Players{}
+	for _, player := range p {
+		if !player.IsComputer() {
+			humans = append(humans, player)
+		}
+	}
+	return humans
+}
+
 // Attributes holds players's attributes.
 //
-// There are two base attributes Attack and Defense.
+// There are three base attributes Attack, Defense and Engine.
 // Attack affects explosion size and maximum shooting power.
 // Defense affects starting amount of armour.
+// Engine affects amount of fuel which can be used for driving in each round.
 //
 // Additionally there is attribute Points.
 // It holds number of points possible to redistribute between other attributes.
 type Attributes struct {
-	Attack, Defense, Points int
+	Attack, Defense, Engine, Points int
 }
 
 // Explosion is value used to calculate size of the bullet explosion.
@@ -60,6 +123,11 @@ func (s *Attributes) Armour() int {
 	return 100 + (s.Defense-1)*5
 }
 
+// Fuel is number of cells which tank can drive in one round
+func (s *Attributes) Fuel() int {
+	return 20 + (s.Engine-1)*5
+}
+
 // Stats are player statistics collected during multiple rounds
 type Stats struct {
 	// how many times player hits some enemy
@@ -68,4 +136,6 @@ type Stats struct {
 	Deaths int
 	// how many times player killed himself
 	Suicides int
+	// how many hit points player took from his enemies
+	Damage int
 }
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"math"
+
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Projectile is engine independent model of the projectile fired by the tank
+type Projectile struct {
+	// Shooter is the tank which fired this projectile
+	Shooter *Tank
+	// Weapon is the name of the weapon which created this projectile
+	Weapon string
+	// Strength is the strength of the explosion
+	Strength int
+	// Angle is the angle used to fire this projectile
+	Angle int
+	// Speed is the initial speed of this projectile
+	Speed float64
+}
+
+// Velocity returns initial velocity of this projectile given by angle and speed
+func (p *Projectile) Velocity() gmath.Vector2f {
+	theta := 2.0 * math.Pi * (float64(p.Angle) / 360.0)
+	return gmath.Vector2f{X: math.Cos(theta) * p.Speed, Y: math.Sin(theta) * -p.Speed}
+}
+
+// Report lets know shooting player where this projectile finished it's flight.
+// Flag lost should be true if projectile left the world without explosion.
+func (p *Projectile) Report(position gmath.Vector2f, lost bool) {
+	p.Shooter.Player.ReportShot(Shot{
+		Angle: p.Angle,
+		Power: int(p.Speed),
+		X:     int(position.X),
+		Y:     int(position.Y),
+		Lost:  lost,
+	})
+}
+
+// Explode lets know that this projectile exploded at given position
+func (p *Projectile) Explode(position gmath.Vector2i) {
+	p.Shooter.events.Push(ProjectileExploded{Projectile: p, Position: position})
+}
+
+// Explosion is engine independent model of the explosion which damages tanks around
+type Explosion struct {
+	// Center is the point where explosion is starting
+	Center gmath.Vector2i
+	// Strength defines maximum radius which explosion can take
+	Strength float64
+	// Shooter is tank who caused this explosion and will be rewarded for the damage, it can be nil
+	Shooter *Tank
+}
+
+// MaxDamage returns maximum amount of damage which can be taken by this explosion
+func (e *Explosion) MaxDamage() float64 {
+	return 100 + e.Strength*10
+}
+
+// Damage returns damage taken by this explosion to the tank with given center.
+// Damage is affected by the distance from the center of explosion and by the strength of explosion.
+func (e *Explosion) Damage(target gmath.Vector2f) int {
+	d := e.Center.As2F().Distance(target.Translate(0, -(float64(e.Center.Y)-target.Y)/2))
+	return int(math.Max(0, (e.Strength-d)/e.Strength) * e.MaxDamage())
+}
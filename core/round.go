@@ -0,0 +1,127 @@
+package core
+
+// Round is engine independent model of one round in the game.
+// It holds the tanks of all players and implements turn state machine and scoring.
+type Round struct {
+	// Index is the index of the round, starting from zero for the first round
+	Index int
+	// Tanks contains tanks of all players in the same order as players are
+	Tanks []*Tank
+	// State holds the state of this round
+	State RoundState
+	// StartingPlayer is index of the player which is first on turn in this round
+	StartingPlayer int
+	// OnTurnPlayer is index of the player currently on turn
+	OnTurnPlayer int
+	// events collects events happened in this round
+	events *Events
+	// restored is true when tanks were restored from the snapshot, they have their shields already equipped then
+	restored bool
+}
+
+// RoundState represents state of the round
+type RoundState uint8
+
+const (
+	// Started round is state right after round was created / started / restarted
+	Started RoundState = iota
+	// PlayerOnTurn is state when some player is on turn but it has not done his move yet
+	PlayerOnTurn
+	// WaitForTurnFinish is state when some player did his move and we are waiting for all consequences of the move
+	WaitForTurnFinish
+	// Finished is state when round was finished which means there is only one or zero tanks alive
+	Finished
+)
+
+// NewRound creates new round in Started state.
+// Events happened in the round will be pushed to given events.
+func NewRound(index int, tanks []*Tank, startingPlayer int, events *Events) *Round {
+	return &Round{
+		Index:          index,
+		Tanks:          tanks,
+		StartingPlayer: startingPlayer,
+		OnTurnPlayer:   startingPlayer,
+		events:         events,
+	}
+}
+
+// Update moves round to the next state if possible.
+// Parameter turnFinished tells if all consequences of the move were already finished (e.g. there are no flying projectiles).
+func (r *Round) Update(turnFinished bool) {
+	switch r.State {
+	case Started:
+		r.State = PlayerOnTurn
+		if !r.restored {
+			for _, t := range r.Tanks {
+				t.EquipShield()
+			}
+		}
+		r.events.Push(RoundStarted{Round: r})
+		r.events.Push(TurnStarted{Tank: r.ActiveTank()})
+	case PlayerOnTurn:
+		if r.ActiveTank().State == Shooting {
+			r.State = WaitForTurnFinish
+		}
+	case WaitForTurnFinish:
+		if !turnFinished {
+			return
+		}
+		if r.NumberOfTanksAlive() <= 1 {
+			r.State = Finished
+			r.events.Push(RoundFinished{Round: r})
+			return
+		}
+		r.State = PlayerOnTurn
+		r.ActivateNextTank()
+		r.events.Push(TurnStarted{Tank: r.ActiveTank()})
+	}
+}
+
+// Score adds statistics and money gained in this round to the players.
+// All players that didn't made suicide gain one point, winner gains one more point and the shield.
+// Money earned in this round is added to the money left from previous rounds increased by given interest rate in percents.
+func (r *Round) Score(interest float64) {
+	for _, tank := range r.Tanks {
+		player := tank.Player
+		player.AddStats(tank.Stats)
+		player.AddInterest(interest)
+		player.Money += tank.Stats.Earnings()
+		if tank.Stats.Suicides == 0 {
+			player.Attributes.Points++
+		}
+		if tank.IsAlive() {
+			player.Attributes.Points++
+			player.Inventory.Add(AwardedShield, 1)
+		}
+	}
+}
+
+// MarkRestored lets know that tanks of this round were restored from the snapshot.
+// Shields are not equipped when restored round starts as they were restored together with the tanks.
+func (r *Round) MarkRestored() {
+	r.restored = true
+}
+
+// ActiveTank returns tank which is currently on turn
+func (r *Round) ActiveTank() *Tank {
+	return r.Tanks[r.OnTurnPlayer]
+}
+
+// NumberOfTanksAlive returns how many tanks is still alive (in game)
+func (r *Round) NumberOfTanksAlive() int {
+	alive := 0
+	for _, t := range r.Tanks {
+		if t.IsAlive() {
+			alive++
+		}
+	}
+	return alive
+}
+
+// ActivateNextTank moves turn to nearest tank which is alive
+func (r *Round) ActivateNextTank() {
+	r.OnTurnPlayer = (r.OnTurnPlayer + 1) % len(r.Tanks)
+	if !r.ActiveTank().IsAlive() && r.NumberOfTanksAlive() > 0 {
+		r.ActivateNextTank()
+	}
+}
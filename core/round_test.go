@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zladovan/gorched/gmath"
+)
+
+// newTestRound creates round with given number of tanks where tanks with given indexes are dead
+func newTestRound(tanks int, dead []int, events *Events) *Round {
+	ts := make([]*Tank, tanks)
+	for i := range ts {
+		ts[i] = NewTank(NewPlayer(fmt.Sprintf("Player %d", i+1)), gmath.Vector2f{}, 0, events)
+	}
+	for _, i := range dead {
+		ts[i].State = Dead
+	}
+	return NewRound(0, ts, 0, events)
+}
+
+// eventNames returns names of the types of all given events
+func eventNames(events []Event) []string {
+	names := []string{}
+	for _, e := range events {
+		names = append(names, fmt.Sprintf("%T", e))
+	}
+	return names
+}
+
+func TestRoundUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		tanks        int
+		dead         []int
+		state        RoundState
+		onTurn       int
+		shooting     bool
+		turnFinished bool
+		wantState    RoundState
+		wantOnTurn   int
+		wantEvents   []string
+	}{
+		{
+			name: "started round gives turn to the starting player", tanks: 2,
+			state: Started, onTurn: 1,
+			wantState: PlayerOnTurn, wantOnTurn: 1,
+			wantEvents: []string{"core.RoundStarted", "core.TurnStarted"},
+		},
+		{
+			name: "player on turn did not shoot yet", tanks: 2,
+			state: PlayerOnTurn, onTurn: 0,
+			wantState: PlayerOnTurn, wantOnTurn: 0,
+		},
+		{
+			name: "player on turn shot", tanks: 2,
+			state: PlayerOnTurn, onTurn: 0, shooting: true,
+			wantState: WaitForTurnFinish, wantOnTurn: 0,
+		},
+		{
+			name: "turn is not finished yet", tanks: 2,
+			state: WaitForTurnFinish, onTurn: 0,
+			wantState: WaitForTurnFinish, wantOnTurn: 0,
+		},
+		{
+			name: "next tank is on turn", tanks: 3,
+			state: WaitForTurnFinish, onTurn: 0, turnFinished: true,
+			wantState: PlayerOnTurn, wantOnTurn: 1,
+			wantEvents: []string{"core.TurnStarted"},
+		},
+		{
+			name: "dead tank is skipped", tanks: 3, dead: []int{1},
+			state: WaitForTurnFinish, onTurn: 0, turnFinished: true,
+			wantState: PlayerOnTurn, wantOnTurn: 2,
+			wantEvents: []string{"core.TurnStarted"},
+		},
+		{
+			name: "turn goes back to the first tank", tanks: 3,
+			state: WaitForTurnFinish, onTurn: 2, turnFinished: true,
+			wantState: PlayerOnTurn, wantOnTurn: 0,
+			wantEvents: []string{"core.TurnStarted"},
+		},
+		{
+			name: "round is finished when one tank is alive", tanks: 3, dead: []int{0, 2},
+			state: WaitForTurnFinish, onTurn: 0, turnFinished: true,
+			wantState: Finished, wantOnTurn: 0,
+			wantEvents: []string{"core.RoundFinished"},
+		},
+		{
+			name: "round is finished when no tank is alive", tanks: 2, dead: []int{0, 1},
+			state: WaitForTurnFinish, onTurn: 1, turnFinished: true,
+			wantState: Finished, wantOnTurn: 1,
+			wantEvents: []string{"core.RoundFinished"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &Events{}
+			published := []Event{}
+			events.Subscribe(func(e Event) { published = append(published, e) })
+			r := newTestRound(tt.tanks, tt.dead, events)
+			r.State = tt.state
+			r.StartingPlayer = tt.onTurn
+			r.OnTurnPlayer = tt.onTurn
+			if tt.shooting {
+				r.ActiveTank().State = Shooting
+			}
+
+			r.Update(tt.turnFinished)
+			events.Publish()
+
+			if r.State != tt.wantState {
+				t.Errorf("State = %d, want %d", r.State, tt.wantState)
+			}
+			if r.OnTurnPlayer != tt.wantOnTurn {
+				t.Errorf("OnTurnPlayer = %d, want %d", r.OnTurnPlayer, tt.wantOnTurn)
+			}
+			if got := eventNames(published); fmt.Sprint(got) != fmt.Sprint(tt.wantEvents) {
+				t.Errorf("events = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestRoundScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		dead       bool
+		stats      Stats
+		wantPoints int
+		wantShield int
+	}{
+		{name: "winner gains two points and the shield", stats: Stats{Kills: 1, Damage: 30}, wantPoints: 2, wantShield: 1},
+		{name: "killed player gains one point", dead: true, stats: Stats{Deaths: 1, Damage: 20}, wantPoints: 1},
+		{name: "player who made suicide gains no point", dead: true, stats: Stats{Deaths: 1, Suicides: 1}, wantPoints: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRound(1, nil, &Events{})
+			tank := r.Tanks[0]
+			if tt.dead {
+				tank.State = Dead
+			}
+			tank.Stats = tt.stats
+
+			r.Score(0)
+
+			player := tank.Player
+			if player.Attributes.Points != tt.wantPoints {
+				t.Errorf("Points = %d, want %d", player.Attributes.Points, tt.wantPoints)
+			}
+			if got := player.Inventory.Ammo(AwardedShield); got != tt.wantShield {
+				t.Errorf("awarded shields = %d, want %d", got, tt.wantShield)
+			}
+			if player.Stats != tt.stats {
+				t.Errorf("Stats = %+v, want %+v", player.Stats, tt.stats)
+			}
+		})
+	}
+}
+
+func TestRoundEquipShields(t *testing.T) {
+	tests := []struct {
+		name         string
+		restored     bool
+		shields      int
+		shield       Shield
+		wantStrength int
+		wantLeft     int
+	}{
+		{name: "no shield in the inventory", shields: 0, wantStrength: 0, wantLeft: 0},
+		{name: "shield is equipped when round starts", shields: 2, wantStrength: 40, wantLeft: 1},
+		{
+			name: "restored shield is kept", restored: true, shields: 2,
+			shield: Shield{Name: AwardedShield, Strength: 15}, wantStrength: 15, wantLeft: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRound(2, nil, &Events{})
+			tank := r.Tanks[0]
+			tank.Player.Inventory.Add(AwardedShield, tt.shields)
+			tank.Shield = tt.shield
+			if tt.restored {
+				r.MarkRestored()
+			}
+
+			r.Update(false)
+
+			if tank.Shield.Strength != tt.wantStrength {
+				t.Errorf("Shield.Strength = %d, want %d", tank.Shield.Strength, tt.wantStrength)
+			}
+			if got := tank.Player.Inventory.Ammo(AwardedShield); got != tt.wantLeft {
+				t.Errorf("shields left = %d, want %d", got, tt.wantLeft)
+			}
+		})
+	}
+}
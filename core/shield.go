@@ -0,0 +1,66 @@
+package core
+
+import "github.com/zladovan/gorched/gmath"
+
+// ShieldKind defines how the shield protects the tank
+type ShieldKind uint8
+
+const (
+	// PlainShield only absorbs the damage
+	PlainShield ShieldKind = iota
+	// Deflector absorbs the damage and pushes explosions away from the tank
+	Deflector
+	// ForceField absorbs the damage and bounces projectiles away from the tank
+	ForceField
+)
+
+// Shield protects the tank by absorbing the damage until its strength is used up.
+// Shields are items in the player's inventory and the strongest one is used by the tank when the round starts.
+type Shield struct {
+	// Name is the name of the item in the player's inventory
+	Name string
+	// Kind defines how the shield protects the tank
+	Kind ShieldKind
+	// Strength is amount of damage which can be still absorbed, shield is not active when it's zero
+	Strength int
+}
+
+// Shields holds all shields which players can own ordered from the strongest one
+var Shields = []Shield{
+	{Name: "Force Field", Kind: ForceField, Strength: 60},
+	{Name: "Deflector", Kind: Deflector, Strength: 50},
+	{Name: "Shield", Kind: PlainShield, Strength: 40},
+}
+
+// AwardedShield is name of the shield which is given to the winner of the round
+const AwardedShield = "Shield"
+
+// BounceCost is strength of the force field used up by one bounced projectile
+const BounceCost = 10
+
+// IsActive returns true if the shield can absorb some damage
+func (s *Shield) IsActive() bool {
+	return s.Strength > 0
+}
+
+// Is returns true if the shield is active and it's of given kind
+func (s *Shield) Is(kind ShieldKind) bool {
+	return s.IsActive() && s.Kind == kind
+}
+
+// Absorb reduces strength of the shield by given amount of damage and returns really absorbed part
+func (s *Shield) Absorb(amount int) int {
+	absorbed := gmath.Max(0, gmath.Min(s.Strength, amount))
+	s.Strength -= absorbed
+	return absorbed
+}
+
+// TakeShield takes the strongest shield from this inventory, ok is false if there is no shield
+func (i Inventory) TakeShield() (s Shield, ok bool) {
+	for _, s := range Shields {
+		if i.Take(s.Name) {
+			return s, true
+		}
+	}
+	return Shield{}, false
+}
@@ -0,0 +1,88 @@
+package core
+
+import "testing"
+
+func TestShieldAbsorb(t *testing.T) {
+	tests := []struct {
+		name         string
+		strength     int
+		amount       int
+		wantAbsorbed int
+		wantStrength int
+	}{
+		{name: "inactive shield", strength: 0, amount: 30, wantAbsorbed: 0, wantStrength: 0},
+		{name: "whole damage", strength: 40, amount: 30, wantAbsorbed: 30, wantStrength: 10},
+		{name: "part of damage", strength: 10, amount: 30, wantAbsorbed: 10, wantStrength: 0},
+		{name: "negative damage", strength: 10, amount: -5, wantAbsorbed: 0, wantStrength: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Shield{Strength: tt.strength}
+			if got := s.Absorb(tt.amount); got != tt.wantAbsorbed {
+				t.Errorf("Absorb() = %d, want %d", got, tt.wantAbsorbed)
+			}
+			if s.Strength != tt.wantStrength {
+				t.Errorf("Strength = %d, want %d", s.Strength, tt.wantStrength)
+			}
+		})
+	}
+}
+
+func TestInventoryTakeShield(t *testing.T) {
+	tests := []struct {
+		name      string
+		inventory Inventory
+		want      string
+		wantOk    bool
+		wantLeft  Inventory
+	}{
+		{name: "no shield", inventory: Inventory{"Missile": 2}, wantOk: false, wantLeft: Inventory{"Missile": 2}},
+		{name: "only shield", inventory: Inventory{"Shield": 1}, want: "Shield", wantOk: true, wantLeft: Inventory{"Shield": 0}},
+		{
+			name: "strongest shield", inventory: Inventory{"Shield": 1, "Force Field": 2},
+			want: "Force Field", wantOk: true, wantLeft: Inventory{"Shield": 1, "Force Field": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := tt.inventory.TakeShield()
+			if ok != tt.wantOk {
+				t.Errorf("ok = %t, want %t", ok, tt.wantOk)
+			}
+			if s.Name != tt.want {
+				t.Errorf("Name = %q, want %q", s.Name, tt.want)
+			}
+			for name, ammo := range tt.wantLeft {
+				if got := tt.inventory.Ammo(name); got != ammo {
+					t.Errorf("Ammo(%q) = %d, want %d", name, got, ammo)
+				}
+			}
+		})
+	}
+}
+
+func TestTankBounce(t *testing.T) {
+	tests := []struct {
+		name         string
+		shield       Shield
+		want         bool
+		wantStrength int
+	}{
+		{name: "no shield", want: false},
+		{name: "plain shield", shield: Shield{Kind: PlainShield, Strength: 40}, want: false, wantStrength: 40},
+		{name: "force field", shield: Shield{Kind: ForceField, Strength: 60}, want: true, wantStrength: 60 - BounceCost},
+		{name: "weak force field", shield: Shield{Kind: ForceField, Strength: 5}, want: true, wantStrength: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tank := newTestTank(100, &Events{})
+			tank.Shield = tt.shield
+			if got := tank.Bounce(); got != tt.want {
+				t.Errorf("Bounce() = %t, want %t", got, tt.want)
+			}
+			if tank.Shield.Strength != tt.wantStrength {
+				t.Errorf("Strength = %d, want %d", tank.Shield.Strength, tt.wantStrength)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package core
+
+import "github.com/zladovan/gorched/gmath"
+
+// Tank is engine independent model of the player's tank.
+// It holds tank's health, aim and state and it implements rules of shooting, taking damage and dying.
+type Tank struct {
+	// Player is the player controlling this tank
+	Player *Player
+	// Position is position of the bottom center of the tank
+	Position gmath.Vector2f
+	// Health holds hit points number, when 0 tank is already dead
+	Health int
+	// Angle of cannon, 0 points to the right, 180 to the left
+	Angle int
+	// Power which will be used to shoot, it can be from 0 to the maximal power given by player's attributes
+	Power float64
+	// Fuel is number of cells which tank can still drive in this round
+	Fuel int
+	// Shield protects the tank in this round, it's not active when there is no shield
+	Shield Shield
+	// Parachute is true while the parachute is open
+	Parachute bool
+	// State describes the current state of the tank
+	State TankState
+	// Stats are statistics collected for the player while using this tank
+	Stats Stats
+	// Projectiles is number of projectiles shot by this tank which are still flying
+	Projectiles int
+	// previousState holds state before the last update, it's useful for actions on state transitions
+	previousState TankState
+	// events collects events happened to this tank
+	events *Events
+}
+
+const (
+	// SafeFallHeight is maximal height in cells from which tank can fall without any damage
+	SafeFallHeight = 2
+	// ParachuteItem is name of the item in player's inventory which is used when the tank is falling
+	ParachuteItem = "Parachute"
+	// ParachuteHeight is height of the fall in cells after which the parachute is opened
+	ParachuteHeight = 3
+)
+
+// TankState describes the state of Tank
+type TankState uint8
+
+const (
+	// Idle is the state when tank is doing nothing but it's ready to go
+	Idle TankState = iota
+	// Loading is the state when tank is preparing to shoot and it's power is changing
+	Loading
+	// Shooting is the state when tank will shoot a bullet
+	Shooting
+	// Dead is the state after tank was hit and he is out of game
+	Dead
+)
+
+// NewTank creates tank for given player on given position.
+// Events happened to the tank will be pushed to given events.
+func NewTank(player *Player, position gmath.Vector2f, angle int, events *Events) *Tank {
+	return &Tank{
+		Player:   player,
+		Position: position,
+		Health:   player.Attributes.Armour(),
+		Fuel:     player.Attributes.Fuel(),
+		Angle:    angle,
+		events:   events,
+	}
+}
+
+// Rotate changes cannon's angle by given change, angle is kept between 0 and 180
+func (t *Tank) Rotate(change int) {
+	t.Angle = gmath.Clamp(0, 180, t.Angle+change)
+}
+
+// EquipShield takes the strongest shield from player's inventory and uses it to protect the tank.
+// Actual shield is replaced only if there is some shield in the inventory.
+func (t *Tank) EquipShield() {
+	if s, ok := t.Player.Inventory.TakeShield(); ok {
+		t.Shield = s
+	}
+}
+
+// Bounce uses up the force field strength needed to bounce one projectile.
+// It returns false if tank is not protected by the force field.
+func (t *Tank) Bounce() bool {
+	if !t.Shield.Is(ForceField) {
+		return false
+	}
+	t.events.Push(ShieldDamaged{Tank: t, Amount: t.Shield.Absorb(BounceCost)})
+	return true
+}
+
+// CanDrive returns true if tank is ready to drive, it can drive only when it's not loading or shooting
+func (t *Tank) CanDrive() bool {
+	return t.State == Idle
+}
+
+// Drive takes fuel needed to move tank by one cell.
+// It returns false if there is no fuel left or tank can not drive now.
+func (t *Tank) Drive() bool {
+	if !t.CanDrive() || t.Fuel <= 0 {
+		return false
+	}
+	t.Fuel--
+	return true
+}
+
+// Shoot will start loading when called first time and shoot when called second time.
+func (t *Tank) Shoot() {
+	switch t.State {
+	case Idle:
+		t.State = Loading
+		t.Power = 0
+	case Loading:
+		t.State = Shooting
+	}
+}
+
+// ShootWith shoots right away with given angle and power without loading.
+// Angle is kept between 0 and 180 and power between 0 and the maximal power given by player's attributes.
+// It does nothing if tank is already shooting.
+func (t *Tank) ShootWith(angle int, power float64) {
+	if t.State != Idle && t.State != Loading {
+		return
+	}
+	t.Angle = gmath.Clamp(0, 180, angle)
+	t.Power = gmath.Clampf(0, float64(t.Player.Attributes.Power()), power)
+	t.State = Shooting
+}
+
+// Update advances tank's state by dt seconds.
+// It returns true once right after the tank started shooting, it's time to fire the weapon then.
+func (t *Tank) Update(dt float64) bool {
+	fire := t.State == Shooting && t.previousState != Shooting
+	if t.State == Loading {
+		// increase shooting power
+		// idea is that increase should be faster for each next 5 points
+		t.Power += (10 + t.Power/5) * dt
+		if t.Power >= float64(t.Player.Attributes.Power()) {
+			t.Power = 1
+		}
+	}
+	t.previousState = t.State
+	return fire
+}
+
+// Fire takes ammo of given weapon from player's inventory
+func (t *Tank) Fire(weapon string) {
+	t.Player.Inventory.Take(weapon)
+	t.events.Push(ShotFired{Tank: t, Weapon: weapon})
+}
+
+// Launch should be called for each projectile fired by this tank
+func (t *Tank) Launch() {
+	t.Projectiles++
+}
+
+// Land should be called for each projectile fired by this tank when it finished it's flight.
+// Tank will be ready for the next shot after all launched projectiles landed.
+func (t *Tank) Land() {
+	t.Projectiles--
+	if t.Projectiles == 0 && t.State != Dead {
+		t.State = Idle
+	}
+}
+
+// TakeDamage will reduce this tank's health by given amount and returns really taken amount.
+// Optionally (use nil to ignore) you can specify enemy which caused this damage.
+// If health goes on or below zero tank will go to Dead state.
+func (t *Tank) TakeDamage(amount int, enemy *Tank) int {
+	if amount <= 0 {
+		return 0
+	}
+
+	// shield absorbs the damage first
+	if t.Shield.IsActive() {
+		absorbed := t.Shield.Absorb(amount)
+		t.events.Push(ShieldDamaged{Tank: t, Amount: absorbed})
+		amount -= absorbed
+		if amount <= 0 {
+			return 0
+		}
+	}
+
+	// real amount taken
+	take := gmath.Min(t.Health, amount)
+
+	// decrease health by taken damage
+	t.Health -= take
+	t.events.Push(TankDamaged{Tank: t, Enemy: enemy, Amount: take})
+
+	// enemy is rewarded for taken damage
+	if enemy != nil && enemy != t {
+		enemy.Stats.Damage += take
+	}
+
+	// noting to do more if tank is still alive
+	if t.Health > 0 {
+		return take
+	}
+
+	// deadly take
+	t.Health = 0
+	t.State = Dead
+	t.Stats.Deaths++
+	if t == enemy {
+		t.Stats.Suicides++
+	} else if enemy != nil {
+		enemy.Stats.Kills++
+	}
+	t.events.Push(TankDied{Tank: t, Enemy: enemy})
+	return take
+}
+
+// OpenParachute takes parachute from player's inventory and opens it.
+// It returns false if the parachute is already open or if there is no parachute in the inventory.
+func (t *Tank) OpenParachute() bool {
+	if t.Parachute || !t.Player.Inventory.Take(ParachuteItem) {
+		return false
+	}
+	t.Parachute = true
+	return true
+}
+
+// Fall should be called when tank landed after the fall from given height with given speed.
+// Tank takes the damage if the height is not safe and there was no parachute open.
+// Optionally (use nil to ignore) you can specify enemy which caused the fall, e.g. by destroying the terrain under the tank.
+// It returns really taken damage.
+func (t *Tank) Fall(height, speed float64, enemy *Tank) int {
+	if t.Parachute {
+		t.Parachute = false
+		return 0
+	}
+	if height <= SafeFallHeight {
+		return 0
+	}
+	return t.TakeDamage(int((height-SafeFallHeight)*speed/5), enemy)
+}
+
+// IsAlive returns wether this tank is still in game
+func (t *Tank) IsAlive() bool {
+	return t.State != Dead
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/zladovan/gorched/gmath"
+)
+
+// newTestTank creates tank of the new player with given health
+func newTestTank(health int, events *Events) *Tank {
+	t := NewTank(NewPlayer("Player"), gmath.Vector2f{}, 0, events)
+	t.Health = health
+	return t
+}
+
+func TestTankTakeDamage(t *testing.T) {
+	const (
+		noEnemy = iota
+		self
+		other
+	)
+	tests := []struct {
+		name           string
+		health         int
+		shield         int
+		amount         int
+		enemy          int
+		wantTaken      int
+		wantHealth     int
+		wantShield     int
+		wantDead       bool
+		wantStats      Stats
+		wantEnemyStats Stats
+	}{
+		{
+			name: "no damage", health: 100, amount: 0, enemy: other,
+			wantTaken: 0, wantHealth: 100,
+		},
+		{
+			name: "damage without enemy", health: 100, amount: 30, enemy: noEnemy,
+			wantTaken: 30, wantHealth: 70,
+		},
+		{
+			name: "damage is credited to the enemy", health: 100, amount: 30, enemy: other,
+			wantTaken: 30, wantHealth: 70,
+			wantEnemyStats: Stats{Damage: 30},
+		},
+		{
+			name: "damage to itself is not credited", health: 100, amount: 30, enemy: self,
+			wantTaken: 30, wantHealth: 70,
+		},
+		{
+			name: "shield absorbs whole damage", health: 100, shield: 40, amount: 30, enemy: other,
+			wantTaken: 0, wantHealth: 100, wantShield: 10,
+		},
+		{
+			name: "shield absorbs part of damage", health: 100, shield: 10, amount: 30, enemy: other,
+			wantTaken: 20, wantHealth: 80,
+			wantEnemyStats: Stats{Damage: 20},
+		},
+		{
+			name: "only remaining health is taken", health: 20, amount: 50, enemy: noEnemy,
+			wantTaken: 20, wantHealth: 0, wantDead: true,
+			wantStats: Stats{Deaths: 1},
+		},
+		{
+			name: "kill is credited to the enemy", health: 20, amount: 50, enemy: other,
+			wantTaken: 20, wantHealth: 0, wantDead: true,
+			wantStats:      Stats{Deaths: 1},
+			wantEnemyStats: Stats{Kills: 1, Damage: 20},
+		},
+		{
+			name: "suicide", health: 20, amount: 20, enemy: self,
+			wantTaken: 20, wantHealth: 0, wantDead: true,
+			wantStats: Stats{Deaths: 1, Suicides: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &Events{}
+			tank := newTestTank(tt.health, events)
+			tank.Shield = Shield{Name: AwardedShield, Strength: tt.shield}
+			var enemy *Tank
+			switch tt.enemy {
+			case self:
+				enemy = tank
+			case other:
+				enemy = newTestTank(100, events)
+			}
+
+			taken := tank.TakeDamage(tt.amount, enemy)
+
+			if taken != tt.wantTaken {
+				t.Errorf("taken = %d, want %d", taken, tt.wantTaken)
+			}
+			if tank.Health != tt.wantHealth {
+				t.Errorf("Health = %d, want %d", tank.Health, tt.wantHealth)
+			}
+			if tank.Shield.Strength != tt.wantShield {
+				t.Errorf("Shield.Strength = %d, want %d", tank.Shield.Strength, tt.wantShield)
+			}
+			if tank.IsAlive() == tt.wantDead {
+				t.Errorf("IsAlive() = %t, want %t", tank.IsAlive(), !tt.wantDead)
+			}
+			if tank.Stats != tt.wantStats {
+				t.Errorf("Stats = %+v, want %+v", tank.Stats, tt.wantStats)
+			}
+			if tt.enemy == other && enemy.Stats != tt.wantEnemyStats {
+				t.Errorf("enemy Stats = %+v, want %+v", enemy.Stats, tt.wantEnemyStats)
+			}
+		})
+	}
+}
+
+func TestTankFall(t *testing.T) {
+	tests := []struct {
+		name          string
+		height        float64
+		speed         float64
+		parachute     bool
+		wantTaken     int
+		wantParachute bool
+	}{
+		{name: "safe height", height: SafeFallHeight, speed: 20, wantTaken: 0},
+		{name: "unsafe height", height: 7, speed: 20, wantTaken: 20},
+		{name: "damage is rounded down", height: 4, speed: 7, wantTaken: 2},
+		{name: "open parachute prevents damage and closes", height: 7, speed: 20, parachute: true, wantTaken: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tank := newTestTank(100, &Events{})
+			tank.Parachute = tt.parachute
+
+			taken := tank.Fall(tt.height, tt.speed, nil)
+
+			if taken != tt.wantTaken {
+				t.Errorf("taken = %d, want %d", taken, tt.wantTaken)
+			}
+			if tank.Health != 100-tt.wantTaken {
+				t.Errorf("Health = %d, want %d", tank.Health, 100-tt.wantTaken)
+			}
+			if tank.Parachute != tt.wantParachute {
+				t.Errorf("Parachute = %t, want %t", tank.Parachute, tt.wantParachute)
+			}
+		})
+	}
+}
+
+func TestTankOpenParachute(t *testing.T) {
+	tests := []struct {
+		name       string
+		parachutes int
+		open       bool
+		want       bool
+		wantLeft   int
+	}{
+		{name: "no parachute", parachutes: 0, want: false, wantLeft: 0},
+		{name: "parachute is taken from the inventory", parachutes: 2, want: true, wantLeft: 1},
+		{name: "parachute is already open", parachutes: 2, open: true, want: false, wantLeft: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tank := newTestTank(100, &Events{})
+			tank.Player.Inventory.Add(ParachuteItem, tt.parachutes)
+			tank.Parachute = tt.open
+
+			if got := tank.OpenParachute(); got != tt.want {
+				t.Errorf("OpenParachute() = %t, want %t", got, tt.want)
+			}
+			if wantOpen := tt.want || tt.open; tank.Parachute != wantOpen {
+				t.Errorf("Parachute = %t, want %t", tank.Parachute, wantOpen)
+			}
+			if got := tank.Player.Inventory.Ammo(ParachuteItem); got != tt.wantLeft {
+				t.Errorf("parachutes left = %d, want %d", got, tt.wantLeft)
+			}
+		})
+	}
+}
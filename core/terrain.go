@@ -0,0 +1,68 @@
+package core
+
+import (
+	"math"
+
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Heightmap holds layout of the terrain.
+// Index is x coordinate and value contains all segments on this x ordered from top to bottom.
+type Heightmap [][]Segment
+
+// Segment is one solid part of the terrain on some x described by its top y coordinate and height
+type Segment struct {
+	Y, Height int
+}
+
+// Cut represents vertical line on given X coordinate going from MinY to MaxY which should be cut from the terrain.
+type Cut struct{ X, MinY, MaxY int }
+
+// HoleCuts returns cuts needed to create hole with center at cx and cy coordinates with given radius r.
+func HoleCuts(cx, cy, r int) []Cut {
+	cuts := []Cut{}
+	for ix := -r + 1; ix < r; ix++ {
+		// y coordinate is scaled by 0.5 to reduce terminal's cells ratio 2:1 for height:width
+		iy := int(math.Sqrt(math.Pow(float64(r-1), 2)-math.Pow(float64(ix), 2)) * 0.5)
+		cuts = append(cuts, Cut{X: cx + ix, MinY: cy - iy, MaxY: cy + iy})
+	}
+	return cuts
+}
+
+// Cut returns segments which remain from this segment after given cut.
+// It returns false if cut line is out of this segment.
+// If there was cut remaining segments can be also empty which means that whole segment was destroyed by this cut.
+func (s Segment) Cut(c Cut) ([]Segment, bool) {
+	// is cut line out of segment ?
+	if c.MaxY < s.Y || c.MinY > s.Y+s.Height-1 {
+		return nil, false
+	}
+
+	// local y coordinates of cut hole
+	topy := gmath.Max(0, c.MinY-s.Y)
+	bottomy := gmath.Min(s.Height-1, c.MaxY-s.Y)
+
+	// create two new segments around cut hole, only with non zero height
+	parts := []Segment{}
+	if topy > 0 {
+		parts = append(parts, Segment{Y: s.Y, Height: topy})
+	}
+	if s.Height-bottomy-1 > 0 {
+		parts = append(parts, Segment{Y: s.Y + bottomy + 1, Height: s.Height - bottomy - 1})
+	}
+	return parts, true
+}
+
+// HeightInside returns y coordinate of the top of the nearest segment under given y for given x.
+// It returns bottom if there is no such segment or if x is out of the heightmap.
+func (h Heightmap) HeightInside(x, y, bottom int) int {
+	if x < 0 || x >= len(h) {
+		return bottom
+	}
+	for _, s := range h[x] {
+		if y <= s.Y {
+			return s.Y
+		}
+	}
+	return bottom
+}
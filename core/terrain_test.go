@@ -0,0 +1,96 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSegmentCut(t *testing.T) {
+	// segment covers y coordinates from 10 to 14
+	segment := Segment{Y: 10, Height: 5}
+	tests := []struct {
+		name    string
+		cut     Cut
+		want    []Segment
+		wantCut bool
+	}{
+		{name: "cut above", cut: Cut{MinY: 2, MaxY: 9}, want: nil, wantCut: false},
+		{name: "cut below", cut: Cut{MinY: 15, MaxY: 20}, want: nil, wantCut: false},
+		{name: "cut in the middle", cut: Cut{MinY: 12, MaxY: 12}, want: []Segment{{Y: 10, Height: 2}, {Y: 13, Height: 2}}, wantCut: true},
+		{name: "cut of the top", cut: Cut{MinY: 8, MaxY: 11}, want: []Segment{{Y: 12, Height: 3}}, wantCut: true},
+		{name: "cut of the bottom", cut: Cut{MinY: 13, MaxY: 20}, want: []Segment{{Y: 10, Height: 3}}, wantCut: true},
+		{name: "cut of the first cell", cut: Cut{MinY: 10, MaxY: 10}, want: []Segment{{Y: 11, Height: 4}}, wantCut: true},
+		{name: "cut of the last cell", cut: Cut{MinY: 14, MaxY: 14}, want: []Segment{{Y: 10, Height: 4}}, wantCut: true},
+		{name: "cut of the whole segment", cut: Cut{MinY: 5, MaxY: 20}, want: []Segment{}, wantCut: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cut := segment.Cut(tt.cut)
+			if cut != tt.wantCut {
+				t.Errorf("cut = %t, want %t", cut, tt.wantCut)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("segments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoleCuts(t *testing.T) {
+	tests := []struct {
+		name string
+		r    int
+		want []Cut
+	}{
+		{name: "zero radius", r: 0, want: []Cut{}},
+		{name: "radius one", r: 1, want: []Cut{{X: 10, MinY: 10, MaxY: 10}}},
+		{
+			name: "radius three",
+			r:    3,
+			want: []Cut{
+				{X: 8, MinY: 10, MaxY: 10},
+				{X: 9, MinY: 10, MaxY: 10},
+				{X: 10, MinY: 9, MaxY: 11},
+				{X: 11, MinY: 10, MaxY: 10},
+				{X: 12, MinY: 10, MaxY: 10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HoleCuts(10, 10, tt.r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HoleCuts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeightmapHeightInside(t *testing.T) {
+	heightmap := Heightmap{
+		{},
+		{{Y: 10, Height: 10}},
+		{{Y: 5, Height: 3}, {Y: 12, Height: 8}},
+	}
+	tests := []struct {
+		name string
+		x, y int
+		want int
+	}{
+		{name: "x out of the heightmap on the left", x: -1, y: 0, want: 20},
+		{name: "x out of the heightmap on the right", x: 3, y: 0, want: 20},
+		{name: "no segment", x: 0, y: 0, want: 20},
+		{name: "above the segment", x: 1, y: 0, want: 10},
+		{name: "on the top of the segment", x: 1, y: 10, want: 10},
+		{name: "inside the segment", x: 1, y: 15, want: 20},
+		{name: "above the first segment", x: 2, y: 0, want: 5},
+		{name: "in the hole between segments", x: 2, y: 9, want: 12},
+		{name: "under the last segment", x: 2, y: 20, want: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := heightmap.HeightInside(tt.x, tt.y, 20); got != tt.want {
+				t.Errorf("HeightInside() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
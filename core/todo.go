@@ -1,3 +0,0 @@
-package core
-
-// TODO: move other core game logic (engine independent) here
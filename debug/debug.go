@@ -32,7 +32,7 @@ func (d *debug) Log(s string) {
 	}
 	d.logs = append(d.logs, fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05.000"), s))
 	if d.engine != nil {
-		d.engine.Log(s)
+		d.engine.Log("%s", s)
 	}
 }
 
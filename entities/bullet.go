@@ -2,38 +2,76 @@ package entities
 
 import (
 	"fmt"
-	"math"
 
 	tl "github.com/JoelOtter/termloop"
-	"github.com/zladovan/gorched/debug"
-	"github.com/zladovan/gorched/entities/terrain"
+	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/gmath"
 	"github.com/zladovan/gorched/physics"
 )
 
 // Bullet is entity representing bullet shooted from tank.
+// It's the view of core.Projectile model.
+// Behaviour of the bullet is defined by the weapon which created it.
 type Bullet struct {
 	// tank who shooted this bullet
 	shooter *Tank
+	// weapon which created this bullet
+	weapon Weapon
+	// projectile is model of this bullet
+	projectile *core.Projectile
 	// body is physical body
 	body *physics.Body
-	// strength of the explosion
-	strength int
 	// explosion is created after bullet hit to something
-	explosion *Explosion
+	explosion tl.Drawable
+	// rolling is true while bullet is rolling on the terrain surface instead of flying
+	rolling bool
+	// bounces is number of times the bullet already bounced off the terrain
+	bounces int
 }
 
-// NewBullet creates new bullet.
-func NewBullet(shooter *Tank, p gmath.Vector2i, speed float64, angle int, strength int) *Bullet {
-	theta := 2.0 * math.Pi * (float64(angle) / 360.0)
+// NewBullet creates new bullet of given weapon.
+func NewBullet(shooter *Tank, weapon Weapon, p gmath.Vector2i, speed float64, angle int, strength int) *Bullet {
+	projectile := &core.Projectile{
+		Shooter:  shooter.model,
+		Weapon:   weapon.Name(),
+		Strength: strength,
+		Angle:    angle,
+		Speed:    speed,
+	}
 	return &Bullet{
-		shooter: shooter,
+		shooter:    shooter,
+		weapon:     weapon,
+		projectile: projectile,
 		body: &physics.Body{
 			Position: gmath.Vector2f{X: float64(p.X), Y: float64(p.Y)},
-			Velocity: gmath.Vector2f{X: math.Cos(theta) * speed, Y: math.Sin(theta) * -speed},
+			Velocity: projectile.Velocity(),
 			Mass:     1,
 		},
-		strength: strength,
+	}
+}
+
+// Update lets the weapon control the flight and removes bullet when it exploded or left the world
+func (b *Bullet) Update(level tl.Level, dt float64) {
+	world := level.(ExtendedLevel)
+
+	// if bullet hit somewhere it's dead
+	if b.explosion != nil {
+		world.AddEntity(b.explosion)
+		b.die(world)
+		return
+	}
+
+	// let the weapon control the flight
+	if !b.weapon.Fly(b, world) {
+		b.die(world)
+		return
+	}
+
+	// remove if below the world or too far on the left/right of the world
+	w, h := world.Size()
+	if int(b.body.Position.Y) > h || int(b.body.Position.X) < -100 || int(b.body.Position.X) > w+100 {
+		b.report(true)
+		b.die(world)
 	}
 }
 
@@ -48,14 +86,8 @@ func (b *Bullet) Draw(s *tl.Screen) {
 	// draw bullet symbol
 	s.RenderCell(int(b.body.Position.X), int(b.body.Position.Y), &tl.Cell{Fg: color, Ch: '■'})
 
-	// remove if below the screen or too far on the left/right of the screen
-	sw, sh := s.Size()
-	if int(b.body.Position.Y) > sh || int(b.body.Position.X) < -100 || int(b.body.Position.X) > sw+100 {
-		b.die(s)
-		return
-	}
-
 	// check if out of screen
+	sw, sh := s.Size()
 	if int(b.body.Position.Y) < 0 || int(b.body.Position.X) < 0 || int(b.body.Position.X) > sw {
 		x := gmath.Clampf(0, float64(sw), b.body.Position.X)
 		y := gmath.Clampf(0, float64(sh), b.body.Position.Y)
@@ -76,12 +108,6 @@ func (b *Bullet) Draw(s *tl.Screen) {
 			i++
 		}
 	}
-
-	// if bullet hit somewhere it's dead
-	if b.explosion != nil {
-		s.Level().AddEntity(b.explosion)
-		b.die(s)
-	}
 }
 
 // Tick is not used yet
@@ -104,27 +130,46 @@ func (b *Bullet) ZIndex() int {
 }
 
 // bullet finished his path
-func (b *Bullet) die(s *tl.Screen) {
-	s.Level().RemoveEntity(b)
+func (b *Bullet) die(level tl.Level) {
+	level.RemoveEntity(b)
 }
 
 // Collide check the collisions
 func (b *Bullet) Collide(collision tl.Physical) {
-	b.explosion = NewExplosion(*b.body.Position.As2I(), b.strength+3, b.shooter)
-	b.body.Locked = true
-
-	// collision with tank
-	if target, ok := collision.(*Tank); ok {
-		target.TakeDamage(int(b.explosion.MaxDamage()), b.shooter)
-		b.explosion.AddAlreadyCollided(target)
+	// force field around the tank bounces the bullet away
+	if tank, ok := collision.(*Tank); ok && tank.Bounce(b) {
+		return
 	}
 
-	// colision with terrain
-	if _, ok := collision.(*terrain.Column); ok {
-		bx := int(b.body.Position.X)
-		by := int(b.body.Position.Y)
-		debug.Logf("Ground was hit x=%d y=%d", bx, by)
+	// weapon decides if it's time to explode
+	if !b.weapon.Collide(b, collision) {
+		return
 	}
+
+	b.explode(collision)
+}
+
+// explode lets the weapon create explosion after collision with given object, collision is nil if bullet exploded by itself
+func (b *Bullet) explode(collision tl.Physical) {
+	b.explosion = b.weapon.Explode(b, collision)
+	b.body.Locked = true
+	b.report(false)
+	b.projectile.Explode(*b.body.Position.As2I())
+}
+
+// report lets know shooting player where this bullet finished
+func (b *Bullet) report(lost bool) {
+	b.projectile.Report(b.body.Position, lost)
+}
+
+// Windage returns 1 as bullet is fully affected by the wind
+func (b *Bullet) Windage() float64 {
+	return 1
+}
+
+// Weapon returns weapon which created this bullet
+func (b *Bullet) Weapon() Weapon {
+	return b.weapon
 }
 
 // Body returns physical body of this bullet
@@ -10,10 +10,14 @@ import (
 // Clouds is entity used to shown moving clouds on the sky.
 // Clouds are calculated using open simplex noise function.
 type Clouds struct {
+	// Speed is number of cells per second by which clouds are moving, positive values are moving clouds to the right
+	Speed float64
 	// 2d array of normalized numbers describing "how cloudy" is given pixel
 	points [][]float64
 	// offset in noise function
 	offsetXGlobal float64
+	// offset in noise function of the first point in current points array
+	offsetXPoints int
 	// offset in current points array
 	offsetXCurrent int
 	// generator of clouds
@@ -39,7 +43,7 @@ type CloudsGenerator struct {
 // GenerateClouds will initialize noise function in given generator and use it to create new clouds.
 func GenerateClouds(g *CloudsGenerator) *Clouds {
 	g.noise = osx.NewNormalized(g.Seed)
-	return &Clouds{points: generate(g, 0), generator: g}
+	return &Clouds{points: generate(g, 0), generator: g, Speed: cloudsSpeedNoWind}
 }
 
 func generate(g *CloudsGenerator, offsetX int) [][]float64 {
@@ -83,17 +87,21 @@ func (c *Clouds) Draw(s *tl.Screen) {
 			}
 		}
 	}
-	// move clouds
-	// TODO: parametrize speed (wind)
-	c.offsetXGlobal += 0.5 * s.TimeDelta()
+}
+
+// Update moves clouds, offset is moving in the opposite direction than clouds
+func (c *Clouds) Update(level tl.Level, dt float64) {
+	c.offsetXGlobal -= c.Speed * dt
 }
 
 // Tick updates clouds points if needed
 func (c *Clouds) Tick(e tl.Event) {
-	c.offsetXCurrent = int(c.offsetXGlobal) % len(c.points)
-	// if we shown already more than half points we need to generate new points
-	if c.offsetXCurrent >= len(c.points)/2 {
-		c.points = generate(c.generator, int(c.offsetXGlobal))
-		c.offsetXCurrent = 0
+	offset := int(math.Floor(c.offsetXGlobal))
+	c.offsetXCurrent = offset - c.offsetXPoints
+	// if visible part is not inside current points we need to generate new points around it
+	if c.offsetXCurrent < 0 || c.offsetXCurrent+c.generator.Width > len(c.points) {
+		c.offsetXPoints = offset - c.generator.Width/2
+		c.points = generate(c.generator, c.offsetXPoints)
+		c.offsetXCurrent = offset - c.offsetXPoints
 	}
 }
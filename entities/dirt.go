@@ -0,0 +1,100 @@
+package entities
+
+import (
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Dirt is effect of the dirt bomb which adds ball of the new ground to the terrain.
+// Tanks inside the ball are buried but they are not damaged.
+type Dirt struct {
+	// center is center of the dirt ball
+	center gmath.Vector2i
+	// radius is radius of the dirt ball
+	radius int
+}
+
+// NewDirt creates ball of the dirt with given center and radius
+func NewDirt(center gmath.Vector2i, radius int) *Dirt {
+	return &Dirt{center: center, radius: radius}
+}
+
+// Update adds the dirt to the terrain and removes this entity from the level
+func (d *Dirt) Update(level tl.Level, dt float64) {
+	world := level.(ExtendedLevel)
+	world.Terrain().FillCircle(d.center.X, d.center.Y, d.radius, freeCells(world))
+	level.RemoveEntity(d)
+}
+
+// Draw does nothing now
+func (d *Dirt) Draw(s *tl.Screen) {}
+
+// Tick does nothing now
+func (d *Dirt) Tick(e tl.Event) {}
+
+// Wall is effect of the dirt wall which grows the wall of the new ground up from the place of impact
+type Wall struct {
+	// x is left x coordinate of the wall
+	x int
+	// bottom is y coordinate of the lowest row of the wall
+	bottom int
+	// width is number of columns of the wall
+	width int
+	// height is number of rows of the wall
+	height int
+	// grown is number of rows which were already added to the terrain
+	grown int
+	// t is time in seconds since the last row was added
+	t float64
+}
+
+// wallSpeed is number of rows added to the growing wall per second
+const wallSpeed = 12
+
+// NewWall creates wall with given width and height growing up from given bottom center
+func NewWall(bottom gmath.Vector2i, width, height int) *Wall {
+	return &Wall{x: bottom.X - width/2, bottom: bottom.Y, width: width, height: height}
+}
+
+// Update adds next rows of the wall to the terrain and removes this entity from the level when the wall is complete
+func (w *Wall) Update(level tl.Level, dt float64) {
+	world := level.(ExtendedLevel)
+	w.t += dt
+	for w.t >= 1.0/wallSpeed && w.grown < w.height {
+		w.t -= 1.0 / wallSpeed
+		world.Terrain().FillRect(w.x, w.bottom-w.grown, w.width, 1, freeCells(world))
+		w.grown++
+	}
+	if w.grown >= w.height {
+		level.RemoveEntity(w)
+	}
+}
+
+// Draw does nothing now
+func (w *Wall) Draw(s *tl.Screen) {}
+
+// Tick does nothing now
+func (w *Wall) Tick(e tl.Event) {}
+
+// freeCells returns function which returns true for the cells which should stay empty when new ground is added to the terrain.
+// These are cells occupied by the tanks and cells right above the ground under the trees.
+// Tanks and trees can be buried this way but they don't fall through the new ground.
+func freeCells(world ExtendedLevel) func(x, y int) bool {
+	tanks := world.Tanks()
+	trees := world.Trees()
+	return func(x, y int) bool {
+		for _, t := range tanks {
+			tx, ty := t.Entity.Position()
+			tw, th := t.Entity.Size()
+			if x >= tx && x < tx+tw && y >= ty && y < ty+th {
+				return true
+			}
+		}
+		for _, t := range trees {
+			if x == int(t.body.Position.X) && y == int(t.body.Position.Y)-1 {
+				return true
+			}
+		}
+		return false
+	}
+}
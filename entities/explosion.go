@@ -2,10 +2,10 @@ package entities
 
 import (
 	"math"
-	"time"
 
 	tl "github.com/JoelOtter/termloop"
 	osx "github.com/ojrac/opensimplex-go"
+	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/debug"
 	"github.com/zladovan/gorched/draw"
 	"github.com/zladovan/gorched/entities/terrain"
@@ -13,22 +13,23 @@ import (
 )
 
 // Explosion represent effect of explosion.
+// It's the view of core.Explosion model which calculates the damage.
 type Explosion struct {
-	// Center defines point where explosion is starting
-	Center gmath.Vector2i
-	// Strength defines maximum radius which explosion can take
-	Strength float64
+	// it extends from core.Explosion
+	*core.Explosion
 	// radius is actual radius of explosion
 	radius float64
 	// speed is given in number of explosion cycles per second
 	// one explosion cycle contains explosion and implosion
 	speed float64
-	// t is time in nanoseconds since explosion was created
+	// t is time in seconds since explosion was created
 	t float64
-	// noise holds noise function used for calculating explosion pattern
+	// noise holds noise function used for calculating explosion pattern, it is created on first update
 	noise osx.Noise
 	// terrainCollided is flag for marking that collision with terrain was already applied
 	terrainCollided bool
+	// undermined is true when tanks above the hole in the terrain made by this explosion already know about it
+	undermined bool
 	// collided contains flag for each entity which explosion already collided with
 	collided map[tl.Physical]bool
 	// shooter is tank who caused this explosion and will be rewarded if this explosion will take some damage, can be nil
@@ -39,25 +40,84 @@ type Explosion struct {
 // Given strength defines maximum radius which explosion reaches at it's peak.
 // Optionally you can specify shooter to tank who caused this explosion and will be rewarded if this explosion will take some damage.
 func NewExplosion(center gmath.Vector2i, strength int, shooter *Tank) *Explosion {
+	model := &core.Explosion{Center: center, Strength: float64(strength)}
+	if shooter != nil {
+		model.Shooter = shooter.model
+	}
 	return &Explosion{
-		Center:   center,
-		Strength: float64(strength),
-		speed:    1,
-		noise:    osx.NewNormalized(time.Now().UTC().UnixNano()),
-		collided: map[tl.Physical]bool{},
-		shooter:  shooter,
+		Explosion: model,
+		speed:     1,
+		collided:  map[tl.Physical]bool{},
+		shooter:   shooter,
 	}
 }
 
-// Draw is drawing explosion sprite.
-func (e *Explosion) Draw(s *tl.Screen) {
+// Update increases time of explosion and changes it's radius.
+// Explosion is removed from the level after one explosion cycle.
+func (e *Explosion) Update(level tl.Level, dt float64) {
+	// noise is seeded from the world to have the same explosions for the same game
+	// it's also the time to deflect just created explosion
+	if e.noise == nil {
+		world := level.(ExtendedLevel)
+		e.noise = osx.NewNormalized(world.Random().Int63())
+		e.deflect(world.Tanks())
+	}
+
+	// tanks above the hole in the terrain are undermined by the shooter
+	if e.terrainCollided && !e.undermined {
+		e.undermined = true
+		e.undermine(level.(ExtendedLevel).Tanks())
+	}
+
 	// increase time of explosion
-	e.t += s.TimeDelta()
+	e.t += dt
 
 	// radius is growing with time up to maximum  given by strength and after then it's decreasing
 	e.radius = math.Sin(e.speed*math.Pi*e.t) * (e.Strength + 1)
 	if e.t > 1/e.speed {
-		s.Level().RemoveEntity(e)
+		e.radius = 0
+		level.RemoveEntity(e)
+	}
+}
+
+// deflectorPush is distance in cells by which deflector pushes the explosion away
+const deflectorPush = 4
+
+// deflect pushes center of this explosion away from all given tanks which are protected by the deflector and which would be damaged
+func (e *Explosion) deflect(tanks []*Tank) {
+	for _, t := range tanks {
+		if !t.IsAlive() || !t.model.Shield.Is(core.Deflector) || e.Damage(t.Center()) == 0 {
+			continue
+		}
+		// direction from the tank's center to the explosion, y is scaled by 2 to reduce terminal's cells ratio 2:1 for height:width
+		c := t.Center()
+		d := gmath.Vector2f{X: float64(e.Center.X) - c.X, Y: (float64(e.Center.Y) - c.Y) * 2}
+		l := d.Length()
+		if l == 0 {
+			d, l = gmath.Vector2f{X: 0, Y: -1}, 1
+		}
+		e.Center.X += int(math.Round(d.X / l * deflectorPush))
+		e.Center.Y += int(math.Round(d.Y / l * deflectorPush / 2))
+		debug.Logf("Explosion deflected by tank of %s", t.Player().Name)
+	}
+}
+
+// undermine lets know all given tanks which are above the hole made by this explosion that the shooter destroyed the terrain under them
+func (e *Explosion) undermine(tanks []*Tank) {
+	r := int(e.Strength)
+	for _, t := range tanks {
+		x, _ := t.Position()
+		w, _ := t.Size()
+		if x+w > e.Center.X-r && x < e.Center.X+r {
+			t.Undermine(e.shooter)
+		}
+	}
+}
+
+// Draw is drawing explosion sprite.
+func (e *Explosion) Draw(s *tl.Screen) {
+	// nothing to draw before first update
+	if e.noise == nil {
 		return
 	}
 
@@ -151,18 +211,8 @@ func (e *Explosion) Collide(collision tl.Physical) {
 	// process collisions with each tank only once
 	if !e.collided[collision] {
 		if target, ok := collision.(*Tank); ok {
-			// get middle point of tank
-			tx, ty := target.Position()
-			tw, th := target.Size()
-			tp := &gmath.Vector2f{X: float64(tx) + float64(tw-1)/2, Y: float64(ty) + float64(th-1)/2}
-
-			// calculate distance of explosion center from tank's middle point
-			d := e.Center.As2F().Distance(tp.Translate(0, -(float64(e.Center.Y)-tp.Y)/2))
-
-			// damage to be taken is affected by distance of explosion and by the strength of explosion
-			damage := int(math.Max(0, (e.Strength-d)/e.Strength) * e.MaxDamage())
-			target.TakeDamage(damage, e.shooter)
-			debug.Logf("Explosion collides with tank damage=%d", damage)
+			// damage to be taken is calculated from tank's middle point
+			target.TakeDamage(e.Damage(target.Center()), e.shooter)
 		}
 		e.AddAlreadyCollided(collision)
 	}
@@ -174,11 +224,6 @@ func (e *Explosion) AddAlreadyCollided(p tl.Physical) {
 	e.collided[p] = true
 }
 
-// MaxDamage returns maximum amount of damage which can be taken by this explosion
-func (e *Explosion) MaxDamage() float64 {
-	return 100 + e.Strength*10
-}
-
 // afterPeak returns true if explosion was already in it's biggest radius
 func (e *Explosion) afterPeak() bool {
 	return e.t > 1/e.speed/2
@@ -131,13 +131,19 @@ func (l *TempLabel) ShowNumber(i int) {
 	l.ShowText(fmt.Sprintf("%d", i))
 }
 
+// Update counts down remaining ttl and removes label from the level if it should be removed after ttl
+func (l *TempLabel) Update(level tl.Level, dt float64) {
+	if l.IsVisible() {
+		l.RemainingTTL -= dt
+	} else if l.Remove {
+		level.RemoveEntity(l)
+	}
+}
+
 // Draw draws label if it is not out of ttl
 func (l *TempLabel) Draw(s *tl.Screen) {
 	if l.IsVisible() {
 		l.Label.Draw(s)
-		l.RemainingTTL -= s.TimeDelta()
-	} else if l.Remove {
-		s.Level().RemoveEntity(l)
 	}
 }
 
@@ -170,13 +176,19 @@ func NewFlyingLabel(position gmath.Vector2i, text string, format Formatting) *Fl
 	}
 }
 
-// Draw draws label if it is not out of ttl
-func (l *FlyingLabel) Draw(s *tl.Screen) {
+// Update updates label position based on physical body and counts down remaining ttl
+func (l *FlyingLabel) Update(level tl.Level, dt float64) {
 	// update label y coordinate based on physical body
 	l.TempLabel.SetPosition(*l.body.Position.As2I())
 
-	// draw original label
-	l.TempLabel.Draw(s)
+	// flying label must be removed by itself as level does not know embedded TempLabel
+	if !l.IsVisible() && l.Remove {
+		level.RemoveEntity(l)
+		return
+	}
+
+	// update original label
+	l.TempLabel.Update(level, dt)
 }
 
 // Body returns physical body of this label
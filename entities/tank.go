@@ -3,75 +3,49 @@ package entities
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"strings"
 
 	tl "github.com/JoelOtter/termloop"
 	"github.com/zladovan/gorched/core"
-	"github.com/zladovan/gorched/debug"
 	"github.com/zladovan/gorched/draw"
+	"github.com/zladovan/gorched/entities/terrain"
 	"github.com/zladovan/gorched/gmath"
 	"github.com/zladovan/gorched/physics"
 )
 
-// Tank represents player's entity.
-// It's tank which can change the angle of it's cannon.
-// It can choose the shooting power and shoot bullet with given angle and power.
+// Tank is the view of the player's tank.
+// Rules of aiming, shooting and taking damage are implemented by core.Tank model.
+// This entity draws the model, keeps its position in sync with the physical body and fires weapons.
 type Tank struct {
 	// it extends from termloop.Entity
 	*tl.Entity
-	// player is reference to Player controlling this Tank
-	player *core.Player
-	// stats is used to collect statistics for player while using this tank
-	stats core.Stats
+	// model holds the state of the tank
+	model *core.Tank
 	// body is physical body of the tank used for falling simulation
 	body *physics.Body
-	// health holds hit points number between 0 and 100, when 0 is already dead
-	health int
-	// angle of cannon, 0 points to the right, 180 to the left
-	angle int
-	// power which will be used to shoot bullet, can be 0 - 100
-	power float64
 	// color of this tank
 	color tl.Attr
-	// state describes the current state of Tank
-	state TankState
-	// previousState holds state in previous frame, it's useful for actions on state transitions
-	previousState TankState
 	// label is used to display info about angle, power or to show some message
 	label *TempLabel
 	// asciiOnly if true will change sprite of the tank to the one containing no unicode characters
 	asciiOnly bool
-	// hits contains numbers of taken damage to this tank, they will be used to create flying labels in next frame
-	hits []int
-}
-
-// TankState describes the state of Tank
-type TankState uint8
-
-const (
-	// Idle is the state when tank is doing nothing but it's ready to go
-	Idle TankState = iota
-	// Loading is the state when tank is preparing to shoot and it's power is changing
-	Loading
-	// Shooting is the state when tank will shoot a bullet
-	Shooting
-	// Dead is the state after tank was hit and he is out of game
-	Dead
-)
+	// destroyed is true when dead tank was already replaced by the explosion
+	destroyed bool
+	// underminedBy is tank which destroyed the terrain under this tank, it's credited for the fall damage
+	underminedBy *Tank
+}
 
-// NewTank creates tank for given player.
-func NewTank(player *core.Player, position gmath.Vector2i, angle int, color tl.Attr, asciiOnly bool) *Tank {
+// NewTank creates view for given tank model.
+func NewTank(model *core.Tank, color tl.Attr, asciiOnly bool) *Tank {
+	position := *model.Position.As2I()
 	return &Tank{
-		Entity: tl.NewEntityFromCanvas(position.X-2, position.Y-3, *createCanvas(angle, color, asciiOnly)),
-		player: player,
+		Entity: tl.NewEntityFromCanvas(position.X-2, position.Y-3, *createCanvas(model.Angle, color, asciiOnly)),
+		model:  model,
 		body: &physics.Body{
-			Position: *position.As2F(),
+			Position: model.Position,
 			Mass:     3,
 		},
-		health: player.Attributes.Armour(),
-		angle:  angle,
-		color:  color,
+		color: color,
 		label: &TempLabel{
 			Label: NewLabel(*position.Translate(1, -4), "", Formatting{Color: color}),
 			TTL:   1,
@@ -211,28 +185,220 @@ func (t *Tank) MoveDown() {
 	t.updateAngle(-1)
 }
 
+// RotateTo changes cannon's angle to given angle
+func (t *Tank) RotateTo(angle int) {
+	t.updateAngle(angle - t.model.Angle)
+}
+
 // updates cannon's angle by given change
 func (t *Tank) updateAngle(change int) {
-	// TODO: angle should be updated by delta time to avoid lags
-	t.angle += change
-	if t.angle > 180 {
-		t.angle = 180
-	} else if t.angle < 0 {
-		t.angle = 0
+	t.model.Rotate(change)
+	t.label.ShowNumber(t.model.Angle)
+	t.Entity.SetCanvas(createCanvas(t.model.Angle, t.color, t.asciiOnly))
+}
+
+// SetAim changes cannon's angle and shooting power at once
+func (t *Tank) SetAim(angle, power int) {
+	if angle != t.model.Angle {
+		t.model.Angle = angle
+		t.Entity.SetCanvas(createCanvas(t.model.Angle, t.color, t.asciiOnly))
+	}
+	t.model.Power = float64(power)
+}
+
+// tankMaxClimb is maximal number of cells which tank can climb up or go down when moving by one cell
+const tankMaxClimb = 3
+
+// Drive moves tank by one cell in given direction (-1 left, 1 right) along the terrain in given world.
+// It costs one unit of fuel.
+// Tank does not move when there is a steep slope, tree or another tank in the way.
+// When terrain goes down steeply tank just drives over the edge and falls down.
+func (t *Tank) Drive(world *World, direction int) {
+	if !t.model.CanDrive() {
+		return
+	}
+	if t.model.Fuel <= 0 {
+		t.label.ShowText("No fuel")
+		return
+	}
+
+	// keep whole tank inside the world
+	width, _ := world.Size()
+	x := int(t.body.Position.X) + direction
+	if x < 2 || x > width-4 {
+		return
+	}
+
+	// tank can not drive while it's falling
+	y := int(t.body.Position.Y)
+	terrain := world.Terrain()
+	if t.groundUnder(terrain, int(t.body.Position.X), y) != y {
+		return
+	}
+
+	// find the highest ground under the bottom line which can be reached
+	if ground := t.groundUnder(terrain, x, y-tankMaxClimb); ground <= y+tankMaxClimb {
+		y = ground
+	}
+
+	// check that there is enough space for the tank above the bottom line
+	bx1, bx2 := t.BottomLine()
+	w, h := t.Size()
+	for i := x + bx1; i <= x+bx2; i++ {
+		for j := y - h; j < y; j++ {
+			if terrain.IsInside(i, j) {
+				t.label.ShowText("Too steep")
+				return
+			}
+		}
+	}
+	cx, _ := t.Position()
+	if t.isBlocked(world, cx+direction, y-h, w, h) {
+		return
+	}
+
+	if !t.model.Drive() {
+		return
+	}
+	t.underminedBy = nil
+	t.body.Position.X += float64(direction)
+	t.body.Position.Y = float64(y)
+	t.body.Velocity.Y = 0
+	t.model.Position = t.body.Position
+	t.label.ShowText(fmt.Sprintf("Fuel %d", t.model.Fuel))
+}
+
+// groundUnder returns y coordinate of the highest ground under the bottom line of the tank
+// when tank would be at given x and it would be searched from given y
+func (t *Tank) groundUnder(terrain *terrain.Terrain, x, y int) int {
+	bx1, bx2 := t.BottomLine()
+	ground := terrain.HeightInside(x+bx1, y)
+	for i := x + bx1 + 1; i <= x+bx2; i++ {
+		ground = gmath.Min(ground, terrain.HeightInside(i, y))
 	}
-	t.label.ShowNumber(t.angle)
-	t.Entity.SetCanvas(createCanvas(t.angle, t.color, t.asciiOnly))
+	return ground
+}
+
+// isBlocked returns true if some tree trunk or another tank is inside given rectangle
+func (t *Tank) isBlocked(world *World, x, y, w, h int) bool {
+	for _, e := range world.Entities {
+		switch e := e.(type) {
+		case *Tree:
+			tx := int(e.body.Position.X)
+			ty := int(e.body.Position.Y)
+			_, th := e.Entity.Size()
+			if tx >= x && tx < x+w && ty > y && ty-th < y+h {
+				return true
+			}
+		case *Tank:
+			if e == t || !e.IsAlive() {
+				continue
+			}
+			ex, ey := e.Position()
+			ew, eh := e.Size()
+			if ex < x+w && ex+ew > x && ey < y+h && ey+eh > y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// forceFieldDamping is part of the bullet's speed which is kept after bouncing from the force field
+const forceFieldDamping = 0.8
+
+// Bounce bounces given bullet away when the tank is protected by the force field.
+// Bullet is reflected as if force field would be a circle around the tank's center and it's moved out of the tank.
+// It returns false if there is no force field and the bullet should hit the tank.
+func (t *Tank) Bounce(b *Bullet) bool {
+	if !t.model.Shield.Is(core.ForceField) {
+		return false
+	}
+
+	// normal points from the tank's center to the bullet, y is scaled by 2 to reduce terminal's cells ratio 2:1 for height:width
+	c := t.Center()
+	p := &b.body.Position
+	n := gmath.Vector2f{X: p.X - c.X, Y: (p.Y - c.Y) * 2}
+	if l := n.Length(); l > 0 {
+		n = gmath.Vector2f{X: n.X / l, Y: n.Y / l}
+	} else {
+		n = gmath.Vector2f{X: 0, Y: -1}
+	}
+
+	// bullet is reflected only if it's going towards the tank
+	v := &b.body.Velocity
+	if dot := v.X*n.X + v.Y*n.Y; dot < 0 {
+		t.model.Bounce()
+		v.X = (v.X - 2*dot*n.X) * forceFieldDamping
+		v.Y = (v.Y - 2*dot*n.Y) * forceFieldDamping
+	}
+
+	// move bullet out of the tank to do not collide again
+	x, y := t.Position()
+	w, h := t.Size()
+	for int(p.X) >= x && int(p.X) < x+w && int(p.Y) >= y && int(p.Y) < y+h {
+		p.X += n.X
+		p.Y += n.Y / 2
+	}
+	return true
+}
+
+// NextWeapon selects next weapon from player's inventory
+func (t *Tank) NextWeapon() {
+	t.cycleWeapon(1)
+}
+
+// PreviousWeapon selects previous weapon from player's inventory
+func (t *Tank) PreviousWeapon() {
+	t.cycleWeapon(-1)
+}
+
+// cycleWeapon selects weapon which is given number of steps away from the selected one.
+// Weapons without ammo are skipped.
+func (t *Tank) cycleWeapon(step int) {
+	current := 0
+	for i, w := range Weapons {
+		if w == t.Weapon() {
+			current = i
+		}
+	}
+	player := t.Player()
+	for i := 1; i < len(Weapons); i++ {
+		w := Weapons[(current+step*i+len(Weapons))%len(Weapons)]
+		if player.Inventory.Has(w.Name()) {
+			player.Weapon = w.Name()
+			break
+		}
+	}
+	t.label.ShowText(weaponTitle(t.Weapon(), player.Inventory))
+}
+
+// Weapon returns weapon which will be used for the next shot.
+// If player has no ammo for selected weapon the default BabyMissile is returned.
+func (t *Tank) Weapon() Weapon {
+	player := t.Player()
+	w := WeaponByName(player.Weapon)
+	if w == nil || !player.Inventory.Has(w.Name()) {
+		return BabyMissile
+	}
+	return w
 }
 
 // Shoot will start loading when called first time and shoot bullet when started second time.
 func (t *Tank) Shoot() {
-	switch t.state {
-	case Idle:
-		t.state = Loading
-		t.power = 0
-	case Loading:
-		t.state = Shooting
-	}
+	t.model.Shoot()
+}
+
+// ShootWith shoots right away with given cannon's angle and shooting power without loading
+func (t *Tank) ShootWith(angle, power int) {
+	t.model.ShootWith(angle, float64(power))
+	t.label.ShowNumber(int(t.model.Power))
+	t.Entity.SetCanvas(createCanvas(t.model.Angle, t.color, t.asciiOnly))
+}
+
+// MaxPower returns maximal shooting power given by player's attributes
+func (t *Tank) MaxPower() int {
+	return t.Player().Attributes.Power()
 }
 
 // phrases which are shown when tank's bullet hit some enemy
@@ -245,134 +411,218 @@ var phrasesAfterHit = []string{
 	"Rest in pieces !",
 }
 
-// Hit should be called when this tank kill some enemy
-func (t *Tank) Hit() {
-	t.label.ShowText(phrasesAfterHit[rand.Intn(len(phrasesAfterHit))])
-	t.stats.Kills++
-}
-
 // TakeDamage will reduce this tank's health by given amount.
 // Optionally (use nil to ignore) you can specify enemy which caused this damage.
-// If health goes on or below zero tank will go to Dead state.
 func (t *Tank) TakeDamage(amount int, enemy *Tank) {
-	if amount <= 0 {
-		return
-	}
-
-	// real amount taken
-	// here is the place to apply some reductions e.g. because of shield
-	take := gmath.Min(t.health, amount)
-
-	// decrease health by taken damage
-	t.health -= take
-
-	// add to hits real taken amount to be shown in flying label on next draw
-	t.hits = append(t.hits, -take)
-
-	// noting to do more if tank is still alive
-	if t.health > 0 {
+	if enemy == nil {
+		t.model.TakeDamage(amount, nil)
 		return
 	}
-
-	// deadly take
-	t.health = 0
-	t.state = Dead
-	t.stats.Deaths++
-	if t == enemy {
-		t.stats.Suicides++
-	} else if enemy != nil {
-		enemy.Hit()
-	}
+	t.model.TakeDamage(amount, enemy.model)
 }
 
 // IsAlive returns wether this tank is still in game
 func (t *Tank) IsAlive() bool {
-	return t.state != Dead
+	return t.model.IsAlive()
 }
 
-// Tick is not used now
+// Tick shows health if nothing else is visible on label above tank
 func (t *Tank) Tick(e tl.Event) {
-	// Show health if nothing else is visible on label above tank
 	if !t.label.IsVisible() {
-		points := int(math.Ceil(float64(t.health) / float64(t.player.Attributes.Armour()) * 4))
+		points := int(math.Ceil(float64(t.model.Health) / float64(t.Player().Attributes.Armour()) * 4))
 		spaces := 4 - points
 		t.label.ShowText(strings.Repeat(".", points) + strings.Repeat(" ", spaces))
 	}
 }
 
-// Draw tank
-func (t *Tank) Draw(s *tl.Screen) {
+// Update synchronizes model with physical body, updates the model and fires weapon when model is shooting
+func (t *Tank) Update(level tl.Level, dt float64) {
 	// TODO: simplify by creating label with relative position
-	// update entity and label positions based on body position
+	// update model, entity and label positions based on body position
+	t.model.Position = t.body.Position
 	y := int(t.body.Position.Y) - 3
 	t.Entity.SetPosition(int(t.body.Position.X)-2, y)
-	t.label.SetPosition(gmath.Vector2i{X: t.label.Position().X, Y: y - 1})
-
-	// get the world
-	world := s.Level().(ExtendedLevel)
-
-	switch t.state {
-	case Shooting:
-		if t.previousState != Shooting {
-			// create new bullet
-			debug.Logf("Tank shooting angle=%d power=%f", t.angle, t.power)
-			bullet := NewBullet(t, t.getBulletInitPos(), float64(int(t.power)), t.angle, t.player.Attributes.Explosion())
-			world.AddEntity(bullet)
-			world.OnEntityRemove(bullet, func() {
-				if t.state != Dead {
-					t.state = Idle
-				}
-			})
+	t.label.SetPosition(gmath.Vector2i{X: int(t.body.Position.X) + 1, Y: y - 1})
+
+	// create new bullets with selected weapon when model starts shooting
+	if t.model.Update(dt) {
+		weapon := t.Weapon()
+		t.model.Fire(weapon.Name())
+		for _, bullet := range weapon.Fire(t, t.BulletPosition(t.model.Angle), float64(int(t.model.Power)), t.model.Angle) {
+			t.launch(level.(ExtendedLevel), bullet)
+		}
+	}
+
+	// parachute is opened when the tank is falling from the big height and it slows the fall down
+	if t.body.Velocity.Y > 0 && t.body.Position.Y-t.body.FallStart > core.ParachuteHeight {
+		t.model.OpenParachute()
+	}
+	if t.model.Parachute {
+		t.body.Velocity.Y = math.Min(t.body.Velocity.Y, parachuteSpeed)
+	}
+
+	// show actual power while loading
+	if t.model.State == core.Loading {
+		t.label.ShowNumber(int(t.model.Power))
+	}
+
+	// dead tank is replaced with the explosion and the tomb
+	if !t.model.IsAlive() && !t.destroyed {
+		t.destroyed = true
+		world := level.(ExtendedLevel)
+		explosion := NewExplosion(*t.body.Position.Translate(0, -2).As2I(), 6, nil)
+		world.AddEntity(explosion)
+		world.OnEntityRemove(explosion, func() {
+			world.AddEntity(NewTomb(*t.body.Position.As2I(), t.color))
+		})
+		world.RemoveEntity(t)
+	}
+
+	// count down label visibility
+	t.label.Update(level, dt)
+}
+
+// parachuteSpeed is maximal speed of the fall with open parachute
+const parachuteSpeed = 3
+
+// Landed applies fall damage after the tank landed.
+// Damage is credited to the tank which destroyed the terrain under this tank.
+func (t *Tank) Landed(height, speed float64) {
+	// tank is just lying on the ground
+	if height <= 0 {
+		return
+	}
+	var enemy *core.Tank
+	if t.underminedBy != nil {
+		enemy = t.underminedBy.model
+	}
+	t.underminedBy = nil
+	t.model.Fall(height, speed, enemy)
+}
+
+// Undermine lets know that given enemy destroyed the terrain under this tank.
+// Enemy will be credited for the damage if the tank falls down.
+func (t *Tank) Undermine(enemy *Tank) {
+	t.underminedBy = enemy
+}
+
+// Handle reacts on events happened to the tank model.
+// It shows labels with taken damage and phrases after killing some enemy.
+func (t *Tank) Handle(level tl.Level, e core.Event) {
+	world := level.(ExtendedLevel)
+	switch e := e.(type) {
+	case core.TankDamaged:
+		if e.Tank == t.model {
+			l := NewFlyingLabel(*t.body.Position.Translate(0, -3).As2I(), fmt.Sprintf("%d", -e.Amount), Formatting{Color: t.color})
+			world.AddEntity(l)
 		}
-	case Loading:
-		// increase shooting power
-		// idea is that increase should be faster for each next 5 points
-		t.power += (10 + t.power/5) * s.TimeDelta()
-		if t.power >= float64(t.player.Attributes.Power()) {
-			t.power = 1
+	case core.ShieldDamaged:
+		if e.Tank == t.model && e.Amount > 0 {
+			l := NewFlyingLabel(*t.body.Position.Translate(0, -3).As2I(), fmt.Sprintf("-%d", e.Amount), Formatting{Color: tl.ColorCyan})
+			world.AddEntity(l)
 		}
-		t.label.ShowNumber(int(t.power))
-	case Dead:
-		if t.previousState != Dead {
-			explosion := NewExplosion(*t.body.Position.Translate(0, -2).As2I(), 6, nil)
-			world.AddEntity(explosion)
-			world.OnEntityRemove(explosion, func() {
-				world.AddEntity(NewTomb(*t.body.Position.As2I(), t.color))
-			})
-			world.RemoveEntity(t)
+	case core.TankDied:
+		if e.Enemy == t.model && e.Tank != t.model {
+			t.label.ShowText(phrasesAfterHit[world.Random().Intn(len(phrasesAfterHit))])
 		}
 	}
-	t.previousState = t.state
+}
 
+// Draw tank with the shield around it and with the label above it
+func (t *Tank) Draw(s *tl.Screen) {
 	// draw underlying entity
 	t.Entity.Draw(s)
+	// draw shield on both sides of the tank
+	if t.model.Shield.IsActive() {
+		left, right, color := shieldLook(t.model.Shield.Kind, IsLowColor(s), t.asciiOnly)
+		x, y := t.Entity.Position()
+		w, h := t.Entity.Size()
+		for i := 0; i < h; i++ {
+			s.RenderCell(x-1, y+i, &tl.Cell{Fg: color | tl.AttrBold, Ch: left})
+			s.RenderCell(x+w, y+i, &tl.Cell{Fg: color | tl.AttrBold, Ch: right})
+		}
+	}
+	// draw open parachute above the tank
+	if t.model.Parachute {
+		x, y := t.Entity.Position()
+		color := tl.Attr(255)
+		if IsLowColor(s) {
+			color = tl.ColorWhite
+		}
+		canopy, lines := "╭────╮", "╲    ╱"
+		if t.asciiOnly {
+			canopy, lines = ".----.", "\\    /"
+		}
+		for i, row := range []string{canopy, lines} {
+			for j, ch := range []rune(row) {
+				if ch != ' ' {
+					s.RenderCell(x+j, y-3+i, &tl.Cell{Fg: color, Ch: ch})
+				}
+			}
+		}
+	}
 	// draw label above tank
 	t.label.Draw(s)
+}
 
-	// draw potential hit labels caused by taken damage
-	for _, h := range t.hits {
-		l := NewFlyingLabel(*t.body.Position.Translate(0, -3).As2I(), fmt.Sprintf("%d", h), Formatting{Color: t.color})
-		world.AddEntity(l)
+// shieldLook returns characters drawn on the left and on the right side of the tank and their color for given kind of shield
+func shieldLook(kind core.ShieldKind, lowColor, asciiOnly bool) (left, right rune, color tl.Attr) {
+	switch kind {
+	case core.Deflector:
+		left, right, color = '<', '>', tl.Attr(215)
+		if lowColor {
+			color = tl.ColorYellow
+		}
+	case core.ForceField:
+		left, right, color = '░', '░', tl.Attr(87)
+		if asciiOnly {
+			left, right = '#', '#'
+		}
+		if lowColor {
+			color = tl.ColorCyan
+		}
+	default:
+		left, right, color = '(', ')', tl.Attr(255)
+		if lowColor {
+			color = tl.ColorWhite
+		}
 	}
-	t.hits = []int{}
+	return left, right, color
+}
+
+// launch adds given bullet shot by this tank to the world.
+// Tank will be ready for the next shot after all launched bullets are removed from the world.
+func (t *Tank) launch(world ExtendedLevel, bullet *Bullet) {
+	t.model.Launch()
+	world.AddEntity(bullet)
+	world.OnEntityRemove(bullet, func() {
+		t.model.Land()
+	})
 }
 
-// calculates initial position of the bullet
-func (t *Tank) getBulletInitPos() gmath.Vector2i {
+// BulletPosition calculates initial position of the bullet shot with cannon rotated to given angle
+func (t *Tank) BulletPosition(angle int) gmath.Vector2i {
 	x, y := t.Entity.Position()
 	x += 2 // move to the center (almost) of the tank
-	if t.angle >= 75 && t.angle < 105 {
+	if angle >= 75 && angle < 105 {
 		y--
 	}
-	if t.angle < 75 {
+	if angle < 75 {
 		x += 3
 	}
-	if t.angle >= 105 {
+	if angle >= 105 {
 		x -= 2
 	}
 	return gmath.Vector2i{X: x, Y: y}
 }
 
+// Center returns middle point of the tank's collider
+func (t *Tank) Center() gmath.Vector2f {
+	x, y := t.Position()
+	w, h := t.Size()
+	return gmath.Vector2f{X: float64(x) + float64(w-1)/2, Y: float64(y) + float64(h-1)/2}
+}
+
 // Position returns collider position
 func (t *Tank) Position() (int, int) {
 	// position for collider is moved to do not include cannon edge
@@ -404,35 +654,90 @@ func (t *Tank) BottomLine() (int, int) {
 
 // Angle returns angle of tank's cannon
 func (t *Tank) Angle() int {
-	return t.angle
+	return t.model.Angle
 }
 
 // Power returns power which will be used to shoot bullet
 func (t *Tank) Power() int {
-	return int(t.power)
+	return int(t.model.Power)
 }
 
 // IsIdle returns true if tank is in Idle state
 func (t *Tank) IsIdle() bool {
-	return t.state == Idle
+	return t.model.State == core.Idle
 }
 
 // IsLoading returns true if tank is loading now
 func (t *Tank) IsLoading() bool {
-	return t.state == Loading
+	return t.model.State == core.Loading
 }
 
 // IsShooting returns true if tank is shooting now
 func (t *Tank) IsShooting() bool {
-	return t.state == Shooting
+	return t.model.State == core.Shooting
 }
 
 // Stats returns statistics for player while using this tank
 func (t *Tank) Stats() core.Stats {
-	return t.stats
+	return t.model.Stats
 }
 
 // Player returns reference to Player controlling this Tank
 func (t *Tank) Player() *core.Player {
-	return t.player
+	return t.model.Player
+}
+
+// Model returns model of this tank
+func (t *Tank) Model() *core.Tank {
+	return t.model
+}
+
+// TankSnapshot holds state of the tank which is needed to restore it
+type TankSnapshot struct {
+	// X is x coordinate of tank's body
+	X float64
+	// Y is y coordinate of tank's body
+	Y float64
+	// Health is remaining health of the tank
+	Health int
+	// Angle is angle of tank's cannon
+	Angle int
+	// Fuel is remaining fuel of the tank
+	Fuel int
+	// Shield is actual shield of the tank
+	Shield core.Shield
+	// Stats are statistics collected in current round
+	Stats core.Stats
+}
+
+// Snapshot returns current state of this tank
+func (t *Tank) Snapshot() TankSnapshot {
+	return TankSnapshot{
+		X:      t.body.Position.X,
+		Y:      t.body.Position.Y,
+		Health: t.model.Health,
+		Angle:  t.model.Angle,
+		Fuel:   t.model.Fuel,
+		Shield: t.model.Shield,
+		Stats:  t.model.Stats,
+	}
+}
+
+// Restore changes state of this tank to the state from given snapshot.
+// If snapshot has no health left tank will be killed.
+func (t *Tank) Restore(s TankSnapshot) {
+	t.body.Position.X = s.X
+	t.body.Position.Y = s.Y
+	t.model.Position = t.body.Position
+	t.model.Health = s.Health
+	t.model.Fuel = s.Fuel
+	t.model.Shield = s.Shield
+	t.model.Stats = s.Stats
+	t.SetAim(s.Angle, int(t.model.Power))
+	if t.model.Health <= 0 {
+		t.model.Health = 0
+		t.model.State = core.Dead
+	} else if t.model.State != core.Dead {
+		t.model.State = core.Idle
+	}
 }
@@ -1,6 +1,8 @@
 package terrain
 
 import (
+	"math"
+
 	tl "github.com/JoelOtter/termloop"
 	"github.com/zladovan/gorched/gmath"
 	"github.com/zladovan/gorched/physics"
@@ -32,18 +34,15 @@ func NewColumn(terrain *Terrain, x, y int, canvas *tl.Canvas) *Column {
 	}
 }
 
-// Draw draws this column and process possible cuttings
-func (t *Column) Draw(s *tl.Screen) {
+// Update updates body locker and position of entity based on body position
+func (t *Column) Update(level tl.Level, dt float64) {
 	// update body locker
-	t.bodyLocker.Update(s.TimeDelta())
+	t.bodyLocker.Update(dt)
 
 	// update position of entity based on body position if not locked
 	if !t.body.Locked {
 		t.Entity.SetPosition(int(t.body.Position.X), int(t.body.Position.Y)-len((*t.canvas)[0]))
 	}
-
-	// draw entity
-	t.Entity.Draw(s)
 }
 
 // Position returns top-left position of collider
@@ -71,8 +70,18 @@ func (t *Column) MakeHole(cx, cy, r int) {
 	t.terrain.MakeHole(cx, cy, r)
 }
 
+// Terrain returns terrain which this column is part of
+func (t *Column) Terrain() *Terrain {
+	return t.terrain
+}
+
 // SetCanvas changes canvas of this column
 func (t *Column) SetCanvas(canvas *tl.Canvas) {
 	t.canvas = canvas
 	t.Entity.SetCanvas(canvas)
 }
+
+// stick makes this column stay where it is even if there is nothing under it, it will not fall until it's cut
+func (t *Column) stick() {
+	t.bodyLocker.RemainingSeconds = math.Inf(1)
+}
@@ -1,10 +1,8 @@
 package terrain
 
 import (
-	"math"
-
 	tl "github.com/JoelOtter/termloop"
-	"github.com/zladovan/gorched/gmath"
+	"github.com/zladovan/gorched/core"
 )
 
 // Cutter is entity which performs cutting of terrain columns.
@@ -14,28 +12,23 @@ type Cutter struct {
 	cuts    []Cut
 }
 
-// Cut represents horizontal line on given X coordinate going from MinY to MaxY which should be cut from the terrain column.
-type Cut struct{ X, MinY, MaxY int }
+// Cut represents vertical line on given X coordinate going from MinY to MaxY which should be cut from the terrain column.
+type Cut = core.Cut
 
 // CutHole will create hole in terrain with center at cx and cy coordinates with given radius r.
 func (c *Cutter) CutHole(cx, cy, r int) {
-	for ix := -r + 1; ix < r; ix++ {
-		// y coordinate is scaled by 0.5 to reduce terminal's cells ratio 2:1 for height:width
-		iy := int(math.Sqrt(math.Pow(float64(r-1), 2)-math.Pow(float64(ix), 2)) * 0.5)
-		miny := cy - iy
-		maxy := cy + iy
-		x := cx + ix
-		if x < 0 || x >= len(c.terrain.columns) {
+	for _, cut := range core.HoleCuts(cx, cy, r) {
+		if cut.X < 0 || cut.X >= len(c.terrain.columns) {
 			continue
 		}
-		c.Cut(x, miny, maxy)
+		c.Cut(cut.X, cut.MinY, cut.MaxY)
 	}
 }
 
 // Cut will cut column at given x by horizontal line going from miny to maxy.
 // Cutting can result to removing column and to replacing it with zero, one or more new columns.
 // Number of new columns depends on the position of the intersection of line and column.
-// Effects of Cut will be applied on nex frame Draw.
+// Effects of Cut will be applied on next Update.
 func (c *Cutter) Cut(x, miny, maxy int) {
 	c.cuts = append(c.cuts, Cut{X: x, MinY: miny, MaxY: maxy})
 }
@@ -62,8 +55,8 @@ func (c *Cutter) CutFromTop(x, cells int) {
 	column.Entity = tl.NewEntityFromCanvas(x, y+cells, newCanvas)
 }
 
-// Draw is processing all pending cuts
-func (c *Cutter) Draw(s *tl.Screen) {
+// Update is processing all pending cuts
+func (c *Cutter) Update(level tl.Level, dt float64) {
 	// process all pending cuts
 	for _, cut := range c.cuts {
 
@@ -83,11 +76,11 @@ func (c *Cutter) Draw(s *tl.Screen) {
 			// add columns created during cut to new columns and to the level
 			for _, p := range cuttingParts {
 				newcols = append(newcols, p)
-				s.Level().AddEntity(p)
+				level.AddEntity(p)
 			}
 
 			// remove this column from level as it was replaced by new columns or cut off
-			s.Level().RemoveEntity(column)
+			level.RemoveEntity(column)
 		}
 		// replace old columns with new updated columns
 		c.terrain.columns[cut.X] = newcols
@@ -97,6 +90,9 @@ func (c *Cutter) Draw(s *tl.Screen) {
 	c.cuts = []Cut{}
 }
 
+// Draw does nothing now
+func (c *Cutter) Draw(s *tl.Screen) {}
+
 // Tick does nothing now
 func (c *Cutter) Tick(e tl.Event) {}
 
@@ -108,33 +104,18 @@ func doCut(t *Column, c Cut) ([]*Column, bool) {
 	x, y := t.Position()
 	_, h := t.Size()
 
-	// is cut line out of column ?
-	if c.MaxY < y || c.MinY > y+h-1 {
+	// cutting logic is done on the segment of the heightmap
+	segments, isCut := core.Segment{Y: y, Height: h}.Cut(c)
+	if !isCut {
 		return nil, false
 	}
 
-	// init cut
-	cuttingParts := []*Column{}
-
-	// local y coordinates of cut hole
-	topy := gmath.Max(0, c.MinY-y)
-	bottomy := gmath.Min(h-1, c.MaxY-y)
-
-	// create two new columns around cut hole
+	// create new columns from remaining segments with corresponding parts of the canvas
 	canvas := *t.canvas
-	topCol := NewColumn(t.terrain, x, y, &tl.Canvas{canvas[0][:topy]})
-	bottomCol := NewColumn(t.terrain, x, y+bottomy+1, &tl.Canvas{canvas[0][bottomy+1:]})
-
-	// add top column if it has non zero height
-	_, h1 := topCol.Size()
-	if h1 > 0 {
-		cuttingParts = append(cuttingParts, topCol)
-	}
-
-	// add bottom column if it has non zero height
-	_, h2 := bottomCol.Size()
-	if h2 > 0 {
-		cuttingParts = append(cuttingParts, bottomCol)
+	cuttingParts := []*Column{}
+	for _, s := range segments {
+		part := canvas[0][s.Y-y : s.Y-y+s.Height]
+		cuttingParts = append(cuttingParts, NewColumn(t.terrain, x, s.Y, &tl.Canvas{part}))
 	}
 
 	return cuttingParts, true
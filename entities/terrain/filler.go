@@ -0,0 +1,109 @@
+package terrain
+
+import (
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/draw"
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Filler is entity which performs filling of the terrain with new ground.
+// It's the opposite of Cutter, it adds new columns to the empty cells of the terrain.
+type Filler struct {
+	terrain *Terrain
+	fills   []Fill
+}
+
+// Fill represents vertical line on given X coordinate going from MinY to MaxY which should be filled with the ground.
+type Fill struct{ X, MinY, MaxY int }
+
+// FillCircle will fill circle with center at cx and cy coordinates with given radius r.
+// Cells for which given free function returns true are left empty, free can be nil.
+func (f *Filler) FillCircle(cx, cy, r int, free func(x, y int) bool) {
+	for _, line := range core.HoleCuts(cx, cy, r) {
+		f.FillLine(line.X, line.MinY, line.MaxY, free)
+	}
+}
+
+// FillRect will fill rectangle with top-left corner at x and y coordinates with given width w and height h.
+// Cells for which given free function returns true are left empty, free can be nil.
+func (f *Filler) FillRect(x, y, w, h int, free func(x, y int) bool) {
+	for i := x; i < x+w; i++ {
+		f.FillLine(i, y, y+h-1, free)
+	}
+}
+
+// FillLine will fill column at given x by vertical line going from miny to maxy.
+// Cells for which given free function returns true are left empty, free can be nil.
+// Line is cut to the terrain bounds.
+// Effects of FillLine will be applied on next Update.
+func (f *Filler) FillLine(x, miny, maxy int, free func(x, y int) bool) {
+	if x < 0 || x >= len(f.terrain.columns) {
+		return
+	}
+	miny = gmath.Max(0, miny)
+	maxy = gmath.Min(f.terrain.height-1, maxy)
+
+	// line is split to multiple fills around free cells
+	start := miny
+	for y := miny; y <= maxy+1; y++ {
+		if y <= maxy && (free == nil || !free(x, y)) {
+			continue
+		}
+		if y > start {
+			f.fills = append(f.fills, Fill{X: x, MinY: start, MaxY: y - 1})
+		}
+		start = y + 1
+	}
+}
+
+// Update is processing all pending fills
+func (f *Filler) Update(level tl.Level, dt float64) {
+	for _, fill := range f.fills {
+
+		// new columns are created in the gaps between existing columns
+		newcols := []*Column{}
+		y := fill.MinY
+		for _, column := range f.terrain.columns[fill.X] {
+			_, cy := column.Position()
+			_, ch := column.Size()
+			if y < cy && y <= fill.MaxY {
+				part := f.newColumn(fill.X, y, gmath.Min(cy-1, fill.MaxY))
+				newcols = append(newcols, part)
+				level.AddEntity(part)
+			}
+			newcols = append(newcols, column)
+			y = gmath.Max(y, cy+ch)
+		}
+		if y <= fill.MaxY {
+			part := f.newColumn(fill.X, y, fill.MaxY)
+			newcols = append(newcols, part)
+			level.AddEntity(part)
+		}
+
+		// replace old columns with columns including new ones
+		f.terrain.columns[fill.X] = newcols
+	}
+
+	// clear fills as they were already processed
+	f.fills = []Fill{}
+}
+
+// Draw does nothing now
+func (f *Filler) Draw(s *tl.Screen) {}
+
+// Tick does nothing now
+func (f *Filler) Tick(e tl.Event) {}
+
+// newColumn creates column on given x going from miny to maxy with colors given by the depth under miny.
+// New ground sticks where it was added even if there is nothing under it, e.g. above the buried tank.
+func (f *Filler) newColumn(x, miny, maxy int) *Column {
+	p := draw.BlankPrinter(1, maxy-miny+1)
+	for y := miny; y <= maxy; y++ {
+		p.Bg = chooseColor(y-miny, f.terrain.lowColor)
+		p.WritePoint(0, y-miny, ' ')
+	}
+	column := NewColumn(f.terrain, x, miny, p.Canvas)
+	column.stick()
+	return column
+}
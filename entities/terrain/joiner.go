@@ -16,13 +16,13 @@ type Joiner struct {
 
 }
 
-// Draw will perform joining logic if this joiner is enabled
-func (j *Joiner) Draw(s *tl.Screen) {
+// Update will perform joining logic if this joiner is enabled
+func (j *Joiner) Update(level tl.Level, dt float64) {
 	// early exit if not enabled
 	if j.ttl <= 0 {
 		return
 	}
-	j.ttl -= s.TimeDelta()
+	j.ttl -= dt
 
 	for x, columns := range j.terrain.columns {
 		// nothing to join
@@ -46,11 +46,14 @@ func (j *Joiner) Draw(s *tl.Screen) {
 			// joining
 			// canvas of last column is added to the canvas of current colum
 			debug.Logf("Joining terrain columns x=%d y1=%d y2=%d", x, ly, lh)
+			// joined column starts at the top of last column
+			cx, _ := column.Position()
 			column.SetCanvas(&tl.Canvas{append((*last.canvas)[0], (*column.canvas)[0]...)})
+			column.Entity.SetPosition(cx, ly)
 			
 			// replace and remove last column
 			joined[len(joined) - 1] = column
-			s.Level().RemoveEntity(last)
+			level.RemoveEntity(last)
 		}
 
 		// replace original columns with joined
@@ -58,6 +61,9 @@ func (j *Joiner) Draw(s *tl.Screen) {
 	}
 }
 
+// Draw does nothing now
+func (j *Joiner) Draw(s *tl.Screen) {}
+
 // Tick does nothing now
 func (j *Joiner) Tick(e tl.Event) {}
 
@@ -0,0 +1,69 @@
+package terrain
+
+import (
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/draw"
+)
+
+// Snapshot holds layout of all terrain columns.
+// Each segment of the heightmap is one terrain column.
+type Snapshot = core.Heightmap
+
+// Segment is one terrain column described by its top y coordinate and height
+type Segment = core.Segment
+
+// Snapshot returns current layout of terrain columns
+func (t *Terrain) Snapshot() Snapshot {
+	s := make(Snapshot, len(t.columns))
+	for x := range t.columns {
+		s[x] = t.segments(x)
+	}
+	return s
+}
+
+// segments returns segments of all columns on given x ordered from top to bottom
+func (t *Terrain) segments(x int) []Segment {
+	segments := make([]Segment, len(t.columns[x]))
+	for i, c := range t.columns[x] {
+		_, y := c.Position()
+		_, h := c.Size()
+		segments[i] = Segment{Y: y, Height: h}
+	}
+	return segments
+}
+
+// Restore replaces all terrain columns with new columns created by given snapshot.
+// Old columns are removed from given level and new columns are added to it.
+// Colors of restored columns are derived from the depth under the top segment on each x.
+// Columns which don't reach the bottom of the terrain stick where they are as they can be only the ground added by filling.
+func (t *Terrain) Restore(s Snapshot, level tl.Level) {
+	t.cutter.cuts = []Cut{}
+	t.filler.fills = []Fill{}
+	for x, columns := range t.columns {
+		for _, c := range columns {
+			level.RemoveEntity(c)
+		}
+		t.columns[x] = []*Column{}
+		if x >= len(s) {
+			continue
+		}
+		for _, seg := range s[x] {
+			if seg.Height <= 0 {
+				continue
+			}
+			p := draw.BlankPrinter(1, seg.Height)
+			for i := 0; i < seg.Height; i++ {
+				p.Bg = chooseColor(seg.Y+i-s[x][0].Y, t.lowColor)
+				p.WritePoint(0, i, ' ')
+			}
+			c := NewColumn(t, x, seg.Y, p.Canvas)
+			// only the ground added by filling can be above the empty space so it has to stick there again
+			if seg.Y+seg.Height < t.height {
+				c.stick()
+			}
+			t.columns[x] = append(t.columns[x], c)
+			level.AddEntity(c)
+		}
+	}
+}
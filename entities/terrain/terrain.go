@@ -21,17 +21,22 @@ type Terrain struct {
 	columns [][]*Column
 	// cutter provides terrain destruction logic
 	cutter *Cutter
+	// filler provides terrain building logic
+	filler *Filler
 	// joiner provides terrain columns joining logic
 	joiner *Joiner
+	// lowColor is true if terrain uses only 8 colors
+	lowColor bool
 }
 
 // NewTerrain creates new Terrain for given terrain line and height.
 // Terrain line is array where index is x coordinate and value is top y coordinate.
 // Terrain height is maximum y value of terrain (lowest on the screen).
 func NewTerrain(line []int, height int, lowColor bool) *Terrain {
-	terrain := &Terrain{height: height}
+	terrain := &Terrain{height: height, lowColor: lowColor}
 	terrain.columns = make([][]*Column, len(line))
 	terrain.cutter = &Cutter{terrain: terrain}
+	terrain.filler = &Filler{terrain: terrain}
 	terrain.joiner = &Joiner{terrain: terrain}
 
 	// create column for each point in terrain line
@@ -58,19 +63,56 @@ func (t *Terrain) HeightOn(x int) int {
 
 // HeightInside returns y coordinate which will be "in the terrain" on the nearest column under given y for given x
 // It allows to find y coordinate inside terrain hole.
+// Terrain height is returned if there is no column under given y or if x is out of the terrain.
 func (t *Terrain) HeightInside(x, y int) int {
-	if len(t.columns[x]) == 0 {
+	if x < 0 || x >= len(t.columns) {
 		return t.height
 	}
+	// only the heightmap of columns on given x is needed
+	return Snapshot{t.segments(x)}.HeightInside(0, y, t.height)
+}
+
+// surfaceNear returns y coordinate of the top of the column which contains given y or which is the nearest under given y for given x
+func (t *Terrain) surfaceNear(x, y int) int {
 	for _, c := range t.columns[x] {
 		_, cy := c.Position()
-		if y <= cy {
+		_, ch := c.Size()
+		if y < cy+ch {
 			return cy
 		}
 	}
 	return t.height
 }
 
+// Normal estimates normal vector of the terrain surface at given x and y from the heights of the neighbour columns.
+// Given y should be on the surface, heights of the neighbours are taken from their columns nearest to this y.
+// Returned vector has length 1 and it points up from the surface.
+func (t *Terrain) Normal(x, y int) gmath.Vector2f {
+	left := gmath.Max(0, x-1)
+	right := gmath.Min(len(t.columns)-1, x+1)
+	if left == right {
+		return gmath.Vector2f{X: 0, Y: -1}
+	}
+	slope := float64(t.surfaceNear(right, y)-t.surfaceNear(left, y)) / float64(right-left)
+	l := math.Sqrt(slope*slope + 1)
+	return gmath.Vector2f{X: slope / l, Y: -1 / l}
+}
+
+// IsInside returns true if given point is inside some terrain column
+func (t *Terrain) IsInside(x, y int) bool {
+	if x < 0 || x >= len(t.columns) {
+		return false
+	}
+	for _, c := range t.columns[x] {
+		_, cy := c.Position()
+		_, ch := c.Size()
+		if y >= cy && y < cy+ch {
+			return true
+		}
+	}
+	return false
+}
+
 // PositionOn returns position which will be "on the terrain" for given x
 func (t *Terrain) PositionOn(x int) gmath.Vector2i {
 	return gmath.Vector2i{X: x, Y: t.HeightOn(x)}
@@ -78,7 +120,7 @@ func (t *Terrain) PositionOn(x int) gmath.Vector2i {
 
 // Entities returns all entities (columns) which is terrain made of
 func (t *Terrain) Entities() []tl.Drawable {
-	entities := []tl.Drawable{t.cutter, t.joiner}
+	entities := []tl.Drawable{t.cutter, t.filler, t.joiner}
 	for _, cs := range t.columns {
 		for _, c := range cs {
 			entities = append(entities, c)
@@ -107,6 +149,22 @@ func (t *Terrain) MakeHole(cx, cy, r int) {
 	t.joiner.Enable()
 }
 
+// FillCircle will add new ground to the terrain in the circle with center at cx and cy coordinates with given radius r.
+// Cells for which given free function returns true are left empty, free can be nil.
+func (t *Terrain) FillCircle(cx, cy, r int, free func(x, y int) bool) {
+	debug.Logf("Filling circle in the terrain centerx=%d, centery=%d", cx, cy)
+	t.filler.FillCircle(cx, cy, r, free)
+	t.joiner.Enable()
+}
+
+// FillRect will add new ground to the terrain in the rectangle with top-left corner at x and y coordinates with given width w and height h.
+// Cells for which given free function returns true are left empty, free can be nil.
+func (t *Terrain) FillRect(x, y, w, h int, free func(x, y int) bool) {
+	debug.Logf("Filling rectangle in the terrain x=%d, y=%d", x, y)
+	t.filler.FillRect(x, y, w, h, free)
+	t.joiner.Enable()
+}
+
 // Line returns terrain line array where index is x coordinate and value is top y coordinate.
 func (t *Terrain) Line() []int {
 	line := make([]int, len(t.columns))
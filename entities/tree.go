@@ -349,11 +349,10 @@ func createOakTreeCanvas(size int, lowColor bool) tl.Canvas {
 	return *p.Canvas
 }
 
-// Draw only updates entity position based on physical body and draws it
-func (t *Tree) Draw(s *tl.Screen) {
+// Update only updates entity position based on physical body
+func (t *Tree) Update(level tl.Level, dt float64) {
 	w, h := t.Entity.Size()
 	t.Entity.SetPosition(int(t.body.Position.X)-w/2, int(t.body.Position.Y)-h)
-	t.Entity.Draw(s)
 }
 
 // Size returns 0 to make trees not collidable yet
@@ -0,0 +1,305 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/entities/terrain"
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Weapon defines how projectiles are created when tank is shooting and how they fly, collide and explode.
+type Weapon interface {
+	// Name returns unique name of the weapon which is also shown to players
+	Name() string
+	// Fire creates projectiles shot by shooter from given position with given speed and angle
+	Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet
+	// Fly is called in each frame while projectile is flying.
+	// It can change projectile's flight or add new entities to the world.
+	// It returns false if projectile should finish it's flight without explosion.
+	Fly(b *Bullet, world ExtendedLevel) bool
+	// Collide is called when projectile collides with something.
+	// It returns true if projectile should explode.
+	Collide(b *Bullet, collision tl.Physical) bool
+	// Explode creates entity which is added to the world when projectile explodes after collision with given object.
+	// Collision is nil when projectile exploded without hitting anything.
+	Explode(b *Bullet, collision tl.Physical) tl.Drawable
+}
+
+// Missile is weapon shooting one projectile which explodes right after it hits something
+type Missile struct {
+	// Title is name of the missile
+	Title string
+	// Strength is added to the explosion strength given by the shooter's attributes
+	Strength int
+}
+
+// Name returns name of the missile
+func (m *Missile) Name() string {
+	return m.Title
+}
+
+// Fire creates one projectile
+func (m *Missile) Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet {
+	return []*Bullet{NewBullet(shooter, m, position, speed, angle, shooter.Player().Attributes.Explosion()+m.Strength)}
+}
+
+// Fly does nothing special, projectile is just falling
+func (m *Missile) Fly(b *Bullet, world ExtendedLevel) bool {
+	return true
+}
+
+// Collide always returns true as missile explodes on any impact
+func (m *Missile) Collide(b *Bullet, collision tl.Physical) bool {
+	return true
+}
+
+// Explode creates explosion in the place of impact.
+// If missile hits the tank directly it takes maximal damage unless the tank is protected by the deflector.
+func (m *Missile) Explode(b *Bullet, collision tl.Physical) tl.Drawable {
+	explosion := NewExplosion(*b.body.Position.As2I(), b.projectile.Strength+3, b.shooter)
+	if target, ok := collision.(*Tank); ok && !target.model.Shield.Is(core.Deflector) {
+		target.TakeDamage(int(explosion.MaxDamage()), b.shooter)
+		explosion.AddAlreadyCollided(target)
+	}
+	return explosion
+}
+
+// MIRV is missile which splits to multiple smaller warheads when it reaches the top of it's flight
+type MIRV struct {
+	// it extends from Missile
+	*Missile
+	// Warheads is number of warheads created after split
+	Warheads int
+	// Spread is difference of horizontal speed between neighbour warheads
+	Spread float64
+}
+
+// Fire creates one projectile which will be split later
+func (m *MIRV) Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet {
+	return []*Bullet{NewBullet(shooter, m, position, speed, angle, shooter.Player().Attributes.Explosion()+m.Strength)}
+}
+
+// Fly splits projectile to warheads when it starts falling down.
+// Original projectile is removed after split.
+func (m *MIRV) Fly(b *Bullet, world ExtendedLevel) bool {
+	if b.body.Velocity.Y <= 0 {
+		return true
+	}
+	for i := 0; i < m.Warheads; i++ {
+		p := b.projectile
+		warhead := NewBullet(b.shooter, m.Missile, *b.body.Position.As2I(), p.Speed, p.Angle, p.Strength)
+		warhead.body.Velocity = *b.body.Velocity.Translate(m.Spread*(float64(i)-float64(m.Warheads-1)/2), 0)
+		b.shooter.launch(world, warhead)
+	}
+	return false
+}
+
+// Roller is missile which doesn't explode when it hits the terrain but it rolls down the hill.
+// It explodes when it reaches the bottom of the valley or when it hits the tank.
+type Roller struct {
+	// it extends from Missile
+	*Missile
+	// Speed is horizontal speed of rolling in cells per second
+	Speed float64
+}
+
+// Fire creates one projectile which will roll after it lands
+func (r *Roller) Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet {
+	return []*Bullet{NewBullet(shooter, r, position, speed, angle, shooter.Player().Attributes.Explosion()+r.Strength)}
+}
+
+// Fly moves rolling projectile along the terrain line.
+// Projectile explodes when the terrain in front of it goes up.
+// When the terrain in front of it goes steeply down or it ends projectile starts falling again.
+func (r *Roller) Fly(b *Bullet, world ExtendedLevel) bool {
+	if !b.rolling {
+		return true
+	}
+	line := world.Terrain().Line()
+	p := &b.body.Position
+	v := &b.body.Velocity
+	// velocity is used only to hold direction of rolling, force field could bounce it
+	v.Y = 0
+	x := int(p.X)
+	nx := int(p.X + v.X*Step)
+	switch {
+	case x < 0 || x >= len(line) || nx < 0 || nx >= len(line) || line[nx] > line[x]+rollerMaxDrop:
+		b.rolling = false
+		b.body.Locked = false
+	case line[nx] < line[x]:
+		b.explode(nil)
+	default:
+		p.X += v.X * Step
+		p.Y = float64(line[nx] - 1)
+	}
+	return true
+}
+
+// Collide starts rolling when projectile lands on the terrain, it explodes on any other impact
+func (r *Roller) Collide(b *Bullet, collision tl.Physical) bool {
+	column, ok := collision.(*terrain.Column)
+	if !ok || b.rolling {
+		return true
+	}
+
+	// rolling goes down the slope or in the direction of the flight if the terrain is flat
+	x, y := column.Position()
+	n := column.Terrain().Normal(x, y)
+	direction := b.body.Velocity.X
+	if math.Abs(n.X) > rollerFlatness {
+		direction = n.X
+	}
+
+	b.rolling = true
+	b.body.Locked = true
+	b.body.Position = gmath.Vector2f{X: float64(x) + 0.5, Y: float64(y - 1)}
+	b.body.Velocity = gmath.Vector2f{X: math.Copysign(r.Speed, direction), Y: 0}
+	return false
+}
+
+// rollerMaxDrop is maximal number of cells which can roller go down to the next column, it starts falling from higher cliffs
+const rollerMaxDrop = 2
+
+// rollerFlatness is maximal horizontal part of the terrain normal for which is terrain considered to be flat
+const rollerFlatness = 0.1
+
+// Bouncer is missile which bounces off the terrain few times before it explodes
+type Bouncer struct {
+	// it extends from Missile
+	*Missile
+	// Bounces is number of bounces off the terrain before the explosion
+	Bounces int
+	// Elasticity is part of the speed which remains after bounce
+	Elasticity float64
+}
+
+// Fire creates one projectile which will bounce off the terrain
+func (m *Bouncer) Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet {
+	return []*Bullet{NewBullet(shooter, m, position, speed, angle, shooter.Player().Attributes.Explosion()+m.Strength)}
+}
+
+// Collide reflects projectile off the terrain surface until all bounces are used, it explodes on any other impact
+func (m *Bouncer) Collide(b *Bullet, collision tl.Physical) bool {
+	column, ok := collision.(*terrain.Column)
+	if !ok || b.bounces >= m.Bounces {
+		return true
+	}
+	b.bounces++
+
+	// bullet is reflected only if it's going into the terrain
+	x, y := column.Position()
+	n := column.Terrain().Normal(x, y)
+	v := &b.body.Velocity
+	if dot := v.X*n.X + v.Y*n.Y; dot < 0 {
+		v.X = (v.X - 2*dot*n.X) * m.Elasticity
+		v.Y = (v.Y - 2*dot*n.Y) * m.Elasticity
+	}
+
+	// move bullet out of the terrain to do not collide again
+	p := &b.body.Position
+	for column.Terrain().IsInside(int(p.X), int(p.Y)) {
+		p.X += n.X
+		p.Y += n.Y / 2
+	}
+	return false
+}
+
+// DirtBomb is missile which doesn't destroy the terrain but it adds ball of the new ground in the place of impact.
+// Radius of the ball is the same as radius of the explosion of the missile with the same strength.
+type DirtBomb struct {
+	// it extends from Missile
+	*Missile
+}
+
+// Fire creates one projectile with the dirt
+func (m *DirtBomb) Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet {
+	return []*Bullet{NewBullet(shooter, m, position, speed, angle, shooter.Player().Attributes.Explosion()+m.Strength)}
+}
+
+// Explode creates ball of the dirt in the place of impact
+func (m *DirtBomb) Explode(b *Bullet, collision tl.Physical) tl.Drawable {
+	return NewDirt(*b.body.Position.As2I(), b.projectile.Strength+3)
+}
+
+// DirtWall is missile which doesn't destroy the terrain but it grows the wall of the new ground up from the place of impact.
+// Height of the wall is the same as radius of the explosion of the missile with the same strength.
+type DirtWall struct {
+	// it extends from Missile
+	*Missile
+	// Width is number of columns of the wall
+	Width int
+}
+
+// Fire creates one projectile which will grow the wall
+func (m *DirtWall) Fire(shooter *Tank, position gmath.Vector2i, speed float64, angle int) []*Bullet {
+	return []*Bullet{NewBullet(shooter, m, position, speed, angle, shooter.Player().Attributes.Explosion()+m.Strength)}
+}
+
+// Explode creates wall growing up from the place of impact
+func (m *DirtWall) Explode(b *Bullet, collision tl.Physical) tl.Drawable {
+	return NewWall(*b.body.Position.As2I(), m.Width, b.projectile.Strength+3)
+}
+
+// Following weapons are available in the game
+
+// BabyMissile is the default weapon which can be used without limits
+var BabyMissile = &Missile{Title: "Baby Missile"}
+
+// Weapons holds all weapons available in the game in the order in which they are cycled
+var Weapons = []Weapon{
+	BabyMissile,
+	&Missile{Title: "Missile", Strength: 2},
+	&Missile{Title: "Baby Nuke", Strength: 5},
+	&Missile{Title: "Nuke", Strength: 9},
+	&MIRV{Missile: &Missile{Title: "MIRV", Strength: 1}, Warheads: 5, Spread: 3},
+	&Roller{Missile: &Missile{Title: "Roller", Strength: 2}, Speed: 12},
+	&Bouncer{Missile: &Missile{Title: "Bouncer", Strength: 2}, Bounces: 3, Elasticity: 0.7},
+	&DirtBomb{Missile: &Missile{Title: "Dirt Bomb", Strength: 4}},
+	&DirtWall{Missile: &Missile{Title: "Dirt Wall", Strength: 6}, Width: 3},
+}
+
+// Goods holds everything what can be bought in the shop between rounds
+var Goods = []core.Goods{
+	{Name: "Missile", Price: 20, Amount: 2},
+	{Name: "Baby Nuke", Price: 40, Amount: 1},
+	{Name: "Nuke", Price: 90, Amount: 1},
+	{Name: "MIRV", Price: 60, Amount: 1},
+	{Name: "Roller", Price: 30, Amount: 2},
+	{Name: "Bouncer", Price: 30, Amount: 2},
+	{Name: "Dirt Bomb", Price: 25, Amount: 2},
+	{Name: "Dirt Wall", Price: 25, Amount: 2},
+	{Name: "Shield", Price: 30, Amount: 1},
+	{Name: "Deflector", Price: 50, Amount: 1},
+	{Name: "Force Field", Price: 70, Amount: 1},
+	{Name: core.ParachuteItem, Price: 20, Amount: 2},
+}
+
+// WeaponByName returns weapon with given name or nil if there is no such weapon
+func WeaponByName(name string) Weapon {
+	for _, w := range Weapons {
+		if w.Name() == name {
+			return w
+		}
+	}
+	return nil
+}
+
+// NewInventory creates inventory which each player has at the start of the game
+func NewInventory() core.Inventory {
+	return core.Inventory{
+		BabyMissile.Name(): core.Unlimited,
+		"Missile":          2,
+	}
+}
+
+// weaponTitle returns name of the weapon with remaining ammo to be shown to the player
+func weaponTitle(w Weapon, inventory core.Inventory) string {
+	ammo := inventory.Ammo(w.Name())
+	if ammo == core.Unlimited {
+		return w.Name()
+	}
+	return fmt.Sprintf("%s (%d)", w.Name(), ammo)
+}
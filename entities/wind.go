@@ -0,0 +1,59 @@
+package entities
+
+import "math/rand"
+
+// WindMode defines how the wind behaves during the round
+type WindMode uint8
+
+const (
+	// NoWind turns the wind off
+	NoWind WindMode = iota
+	// ConstantWind is generated at the start of each round and it does not change until the end of the round
+	ConstantWind
+	// ChangingWind is generated at the start of each round and then it's changed on each turn
+	ChangingWind
+)
+
+const (
+	// MaxWind is maximal strength of the wind
+	MaxWind = 10
+	// windAcceleration is horizontal acceleration of the bullet caused by the wind with strength 1
+	windAcceleration = 0.3
+	// cloudsSpeedNoWind is speed of clouds when there is no wind
+	cloudsSpeedNoWind = -0.5
+	// cloudsSpeedPerWind is how fast are clouds moving with each point of wind strength
+	cloudsSpeedPerWind = 0.4
+)
+
+// generateWind returns random wind strength for given mode.
+// Strength is from -MaxWind to MaxWind, where negative values are blowing to the left.
+func generateWind(rnd *rand.Rand, mode WindMode) int {
+	if mode == NoWind {
+		return 0
+	}
+	return rnd.Intn(MaxWind*2+1) - MaxWind
+}
+
+// Wind returns strength of the wind in this world.
+// Strength is from -MaxWind to MaxWind, where negative values are blowing to the left.
+func (w *World) Wind() int {
+	return w.wind
+}
+
+// SetWind changes strength of the wind in this world
+func (w *World) SetWind(wind int) {
+	w.wind = wind
+	w.physics.Wind = float64(wind) * windAcceleration
+	w.clouds.Speed = cloudsSpeedNoWind
+	if w.options.Wind != NoWind {
+		w.clouds.Speed = float64(wind) * cloudsSpeedPerWind
+	}
+}
+
+// ChangeWind generates new wind if the wind should be changed on each turn.
+// Otherwise it does nothing.
+func (w *World) ChangeWind() {
+	if w.options.Wind == ChangingWind {
+		w.SetWind(generateWind(w.windRnd, w.options.Wind))
+	}
+}
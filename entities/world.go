@@ -1,18 +1,24 @@
 package entities
 
 import (
+	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 
 	tl "github.com/JoelOtter/termloop"
 	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/debug"
 	"github.com/zladovan/gorched/entities/terrain"
+	"github.com/zladovan/gorched/gmath"
 	"github.com/zladovan/gorched/physics"
 )
 
 // World represents game world with all entities.
 // It extends from termloop.BaseLevel so it can be added to the screen as termloop.Level.
+//
+// State of the world is changed only by calling Update which advances simulation by fixed time Step.
+// Draw only renders actual state so the same inputs will always lead to the same results regardless the frame rate.
 type World struct {
 	*tl.BaseLevel
 	terrain *terrain.Terrain
@@ -21,6 +27,29 @@ type World struct {
 	// entitiesToRemove holds references to entities which will be removed on next Tick
 	entitiesToRemove []tl.Drawable
 	onEntityRemove   map[tl.Drawable]func()
+	// clouds are moving according to the wind
+	clouds *Clouds
+	// wind is actual strength of the wind
+	wind int
+	// windRnd is source of random wind changes
+	windRnd *rand.Rand
+	// rnd is source of random numbers for all entities
+	rnd *rand.Rand
+}
+
+// Step is duration of one simulation step in seconds
+const Step = 1.0 / 60
+
+// Listener is entity which reacts on the events happened in the game model.
+// Handle is called with the level where is the entity placed for each event published while the entity is in the world.
+type Listener interface {
+	Handle(level tl.Level, e core.Event)
+}
+
+// Updater is entity which state is changing with time.
+// Update is called in each simulation step with the level where is the entity placed and with duration of the step.
+type Updater interface {
+	Update(level tl.Level, dt float64)
 }
 
 // WorldOptions provide configuration needed for generating game world (one round).
@@ -35,10 +64,58 @@ type WorldOptions struct {
 	ASCIIOnly bool
 	// LowColor identifies that only 8 colors can be used for world graphics
 	LowColor bool
+	// Wind defines how the wind behaves in the world
+	Wind WindMode
+	// Colors holds custom colors of tanks by the indexes of players, default colors are used for other players
+	Colors map[int]tl.Attr
+	// Tunables holds values affecting physics and generation of the world
+	Tunables Tunables
+}
+
+// Tunables holds values affecting physics and generation of the world.
+// Zero values are replaced by the values from DefaultTunables.
+// Zero TreeDensity is valid value for the world without trees so it's replaced only if no tunables are set at all.
+type Tunables struct {
+	// Gravity is gravitational acceleration pulling bullets and tanks down
+	Gravity float64
+	// Roughness of the terrain, higher values make more hills and valleys
+	Roughness float64
+	// TreeDensity controls how much trees there will be, it should be from 0 to 1
+	TreeDensity float64
+	// TreeSize is maximal height of the tree
+	TreeSize int
+}
+
+// DefaultTunables holds values used for all tunables which are not set
+var DefaultTunables = Tunables{
+	Gravity:     9.81,
+	Roughness:   7.5,
+	TreeDensity: 0.2,
+	TreeSize:    6,
+}
+
+// WithDefaults returns copy of these tunables where zero values are replaced by default values.
+// TreeDensity is replaced only when all tunables are zero, e.g. for games saved before tunables were added.
+func (t Tunables) WithDefaults() Tunables {
+	if t == (Tunables{}) {
+		return DefaultTunables
+	}
+	if t.Gravity == 0 {
+		t.Gravity = DefaultTunables.Gravity
+	}
+	if t.Roughness == 0 {
+		t.Roughness = DefaultTunables.Roughness
+	}
+	if t.TreeSize == 0 {
+		t.TreeSize = DefaultTunables.TreeSize
+	}
+	return t
 }
 
 // NewWorld creates new game world with all entities
 func NewWorld(game core.Game, o WorldOptions) *World {
+	tunables := o.Tunables.WithDefaults()
+
 	// random positions in the world are seeded too
 	rnd := rand.New(rand.NewSource(o.Seed))
 
@@ -47,7 +124,7 @@ func NewWorld(game core.Game, o WorldOptions) *World {
 		Seed:      o.Seed,
 		Width:     o.Width,
 		Height:    o.Height,
-		Roughness: 7.5,
+		Roughness: tunables.Roughness,
 		LowColor:  o.LowColor,
 	})
 
@@ -60,23 +137,27 @@ func NewWorld(game core.Game, o WorldOptions) *World {
 		ASCIIOnly: o.ASCIIOnly,
 	})
 
-	// create players
-	// TODO: update for different player counts
-	tanks := []*Tank{
-		NewTank(
-			game.Players()[0],
-			terrain.PositionOn(10+rnd.Intn(10)),
-			0,
-			tl.ColorRed,
-			o.ASCIIOnly,
-		),
-		NewTank(
-			game.Players()[1],
-			terrain.PositionOn(o.Width-10-rnd.Intn(10)),
-			180,
-			tl.ColorBlack,
+	// create tank for each player
+	events := game.Events()
+	players := game.Players()
+	positions := tankPositions(rnd, len(players), o.Width)
+	tanks := make([]*Tank, len(players))
+	for i, player := range players {
+		// tanks on the left half are aiming to the right and vice versa
+		angle := 0
+		if positions[i] > o.Width/2 {
+			angle = 180
+		}
+		color, ok := o.Colors[i]
+		if !ok {
+			color = tankColors[i%len(tankColors)]
+		}
+		position := terrain.PositionOn(positions[i])
+		tanks[i] = NewTank(
+			core.NewTank(player, *position.As2F(), angle, events),
+			color,
 			o.ASCIIOnly,
-		),
+		)
 	}
 
 	// cut the terrain around the tanks
@@ -90,8 +171,8 @@ func NewWorld(game core.Game, o WorldOptions) *World {
 	trees := GenerateWood(&WoodGenerator{
 		Line:      terrain.Line(),
 		Seed:      o.Seed,
-		Density:   0.2,
-		MaxSize:   6,
+		Density:   tunables.TreeDensity,
+		MaxSize:   uint(tunables.TreeSize),
 		MinSpace:  1,
 		LowColor:  o.LowColor,
 		ASCIIOnly: o.ASCIIOnly,
@@ -112,10 +193,14 @@ func NewWorld(game core.Game, o WorldOptions) *World {
 	world := &World{
 		BaseLevel:      tl.NewBaseLevel(tl.Cell{Bg: bg}),
 		terrain:        terrain,
-		physics:        &physics.Physics{Gravity: 9.81, Ground: terrain.HeightInside},
+		physics:        &physics.Physics{Gravity: tunables.Gravity, Ground: terrain.HeightInside},
 		options:        o,
 		onEntityRemove: map[tl.Drawable]func(){},
+		clouds:         clouds,
+		windRnd:        rand.New(rand.NewSource(o.Seed)),
+		rnd:            rnd,
 	}
+	world.SetWind(generateWind(world.windRnd, o.Wind))
 	world.AddEntity(clouds)
 	for _, c := range terrain.Entities() {
 		world.AddEntity(c)
@@ -127,32 +212,184 @@ func NewWorld(game core.Game, o WorldOptions) *World {
 		world.AddEntity(t)
 	}
 
-	debug.Logf("New world created width=%d height=%d seed=%d", o.Width, o.Height, o.Seed)
+	debug.Logf("New world created width=%d height=%d seed=%d wind=%d", o.Width, o.Height, o.Seed, world.wind)
 
 	return world
 }
 
+// tankColors holds colors of tanks, each player has his own color
+var tankColors = []tl.Attr{
+	tl.ColorRed,
+	tl.ColorBlack,
+	tl.ColorMagenta,
+	tl.ColorYellow,
+	tl.ColorWhite,
+	tl.ColorCyan,
+	tl.ColorGreen | tl.AttrBold,
+	tl.ColorBlue | tl.AttrBold,
+}
+
+// colorNames holds colors which can be used for tanks by their names
+var colorNames = map[string]tl.Attr{
+	"black":   tl.ColorBlack,
+	"red":     tl.ColorRed,
+	"green":   tl.ColorGreen,
+	"yellow":  tl.ColorYellow,
+	"blue":    tl.ColorBlue,
+	"magenta": tl.ColorMagenta,
+	"cyan":    tl.ColorCyan,
+	"white":   tl.ColorWhite,
+}
+
+// ColorByName returns tank color with given name.
+// Name can be one of basic colors (e.g. red) optionally prefixed by "bright-" to use bold variant.
+func ColorByName(name string) (tl.Attr, error) {
+	n := strings.TrimPrefix(strings.ToLower(name), "bright-")
+	color, ok := colorNames[n]
+	if !ok {
+		names := []string{}
+		for n := range colorNames {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return 0, fmt.Errorf("Unknown color '%s', use one of: %s, optionally prefixed by bright-", name, strings.Join(names, ", "))
+	}
+	if n != strings.ToLower(name) {
+		color |= tl.AttrBold
+	}
+	return color, nil
+}
+
+// constants used for placing tanks in the world
+const (
+	// tankEdgeSpace is space on x axis between edge of the world and the outermost tank
+	tankEdgeSpace = 10
+	// tankMinSpace is minimal space on x axis between two neighbour tanks
+	tankMinSpace = 8
+	// tankMaxShift is maximal random shift of tank from it's base position
+	tankMaxShift = 10
+)
+
+// tankPositions returns x coordinates for given count of tanks spread across the world with given width.
+// Tanks are spread equally from left to right edge and each of them is randomly shifted from its base position.
+// Shift is never big enough to get tanks closer than tankMinSpace if the world is wide enough.
+// First tank is shifted only to the right and last tank only to the left to keep some space from world edges.
+func tankPositions(rnd *rand.Rand, count, width int) []int {
+	positions := make([]int, count)
+	if count == 1 {
+		positions[0] = width / 2
+		return positions
+	}
+
+	spacing := float64(width-2*tankEdgeSpace) / float64(count-1)
+	shift := gmath.Clamp(1, tankMaxShift, (int(spacing)-tankMinSpace)/2)
+	for i := range positions {
+		base := tankEdgeSpace + int(float64(i)*spacing)
+		switch i {
+		case 0:
+			positions[i] = base + rnd.Intn(shift)
+		case count - 1:
+			positions[i] = base - rnd.Intn(shift)
+		default:
+			positions[i] = base + rnd.Intn(shift*2) - shift
+		}
+		// keep whole tank inside the world
+		positions[i] = gmath.Clamp(2, width-4, positions[i])
+	}
+	return positions
+}
+
 // RemoveEntity only registers entity to remove.
-// Entity will be removed in next Tick.
-// This is needed for be able to remove entities from Draw method (where level is accessible).
+// Entity will be removed at the end of current Update or in next Tick.
+// This is needed for be able to remove entities from Update method while iterating over all entities.
 func (w *World) RemoveEntity(e tl.Drawable) {
 	w.entitiesToRemove = append(w.entitiesToRemove, e)
 }
 
+// Update advances simulation by one Step.
+// First physics is applied to all entities with bodies.
+// Then all entities implementing Updater are updated.
+// Then entities registered to be removed are removed and collisions are resolved.
+// Entities are always processed in the order in which they were added to make results deterministic.
+func (w *World) Update() {
+	entities := make([]tl.Drawable, len(w.Entities))
+	copy(entities, w.Entities)
+
+	// apply physics to all entities with bodies
+	for _, e := range entities {
+		if entity, ok := e.(physics.HasBody); ok {
+			w.physics.Apply(entity, Step)
+		}
+	}
+
+	// update all entities
+	for _, e := range entities {
+		if entity, ok := e.(Updater); ok {
+			entity.Update(w, Step)
+		}
+	}
+
+	w.removeEntities()
+	w.collide()
+}
+
+// Handle passes given event to all entities implementing Listener
+func (w *World) Handle(e core.Event) {
+	for _, entity := range w.Entities {
+		if listener, ok := entity.(Listener); ok {
+			listener.Handle(w, e)
+		}
+	}
+}
+
+// collide checks collisions of all dynamic physical entities with all other physical entities.
+// Unlike termloop.BaseLevel collisions are checked serially to keep them in deterministic order.
+func (w *World) collide() {
+	colliders := []tl.Physical{}
+	dynamics := []tl.DynamicPhysical{}
+	for _, e := range w.Entities {
+		if p, ok := e.(tl.Physical); ok {
+			colliders = append(colliders, p)
+		}
+		if p, ok := e.(tl.DynamicPhysical); ok {
+			dynamics = append(dynamics, p)
+		}
+	}
+	for _, p := range dynamics {
+		for _, c := range colliders {
+			if c == p {
+				continue
+			}
+			px, py := p.Position()
+			cx, cy := c.Position()
+			pw, ph := p.Size()
+			cw, ch := c.Size()
+			if px < cx+cw && px+pw > cx && py < cy+ch && py+ph > cy {
+				p.Collide(c)
+			}
+		}
+	}
+}
+
+// removeEntities removes all entities registered to be removed and calls their callbacks
+func (w *World) removeEntities() {
+	for _, entity := range w.entitiesToRemove {
+		w.BaseLevel.RemoveEntity(entity)
+		if callback := w.onEntityRemove[entity]; callback != nil {
+			callback()
+			delete(w.onEntityRemove, entity)
+		}
+	}
+	w.entitiesToRemove = nil
+}
+
 // Draw draws all entities in the world.
 // Drawing takes into account z-index of entity which can be specified by implementing ZIndexer interface.
-// Gravity is also applied here as there is access to delta time from screen.
 func (w *World) Draw(s *tl.Screen) {
 	// depthField  will contain entities distributed by z-index
 	depthField := make(map[int][]tl.Drawable, len(w.Entities))
 
 	for _, e := range w.BaseLevel.Entities {
-
-		// apply physics to all entities with bodies
-		if entity, ok := e.(physics.HasBody); ok {
-			w.physics.Apply(entity, s.TimeDelta())
-		}
-
 		// find z-index of entity where 0 is the default
 		zIndex := 0
 		if entity, ok := e.(ZIndexer); ok {
@@ -179,16 +416,13 @@ func (w *World) Draw(s *tl.Screen) {
 }
 
 // Tick first removes all entity previously registered to be removed.
-// Then calls original Tick logic.
+// Then passes event to all entities.
+// Collisions are not checked here as they are part of the Update.
 func (w *World) Tick(e tl.Event) {
-	for _, entity := range w.entitiesToRemove {
-		w.BaseLevel.RemoveEntity(entity)
-		if callback := w.onEntityRemove[entity]; callback != nil {
-			callback()
-			w.onEntityRemove[entity] = nil
-		}
+	w.removeEntities()
+	for _, entity := range w.Entities {
+		entity.Tick(e)
 	}
-	w.BaseLevel.Tick(e)
 }
 
 // OnEntityRemove registers callback f which will be called right after given entity e will be removed from World
@@ -196,6 +430,26 @@ func (w *World) OnEntityRemove(e tl.Drawable, f func()) {
 	w.onEntityRemove[e] = f
 }
 
+// Physics returns physical model used in this world
+func (w *World) Physics() *physics.Physics {
+	return w.physics
+}
+
+// Terrain returns terrain of this world
+func (w *World) Terrain() *terrain.Terrain {
+	return w.terrain
+}
+
+// RestoreTerrain replaces all terrain columns by columns from given snapshot
+func (w *World) RestoreTerrain(s terrain.Snapshot) {
+	w.terrain.Restore(s, w)
+}
+
+// Size returns width and height of this world
+func (w *World) Size() (int, int) {
+	return w.options.Width, w.options.Height
+}
+
 // IsLowColor is helper function for quick access to LowColor world option in Draw methods
 func IsLowColor(s *tl.Screen) bool {
 	if world, ok := s.Level().(*World); ok {
@@ -211,8 +465,41 @@ type ZIndexer interface {
 	ZIndex() int
 }
 
+// Random returns source of random numbers which should be used by all entities in this world.
+// It is seeded by the world seed.
+func (w *World) Random() *rand.Rand {
+	return w.rnd
+}
+
+// Tanks returns all tanks in this world
+func (w *World) Tanks() []*Tank {
+	tanks := []*Tank{}
+	for _, e := range w.Entities {
+		if t, ok := e.(*Tank); ok {
+			tanks = append(tanks, t)
+		}
+	}
+	return tanks
+}
+
+// Trees returns all trees in this world
+func (w *World) Trees() []*Tree {
+	trees := []*Tree{}
+	for _, e := range w.Entities {
+		if t, ok := e.(*Tree); ok {
+			trees = append(trees, t)
+		}
+	}
+	return trees
+}
+
 // ExtendedLevel extends termloop.Level with additional functionality
 type ExtendedLevel interface {
 	tl.Level
 	OnEntityRemove(e tl.Drawable, f func())
+	Random() *rand.Rand
+	Size() (int, int)
+	Tanks() []*Tank
+	Trees() []*Tree
+	Terrain() *terrain.Terrain
 }
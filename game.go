@@ -6,7 +6,9 @@ import (
 	tl "github.com/JoelOtter/termloop"
 	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/debug"
+	"github.com/zladovan/gorched/entities"
 	"github.com/zladovan/gorched/hud"
+	"github.com/zladovan/gorched/keys"
 )
 
 // Game holds information which is kept during whole session.
@@ -23,6 +25,14 @@ type Game struct {
 	controls *Controls
 	// round is responsible for creating and managing state of game rounds
 	round *Round
+	// clock drives the simulation with fixed time step
+	clock *Clock
+	// bot controls computer players, it's nil if there are no computer players
+	bot *Bot
+	// events is the bus of all events happened in the game
+	events *core.Events
+	// savePath is path of the file where the game is saved, saving is not possible if it's empty
+	savePath string
 }
 
 // GameOptions provide configuration needed for creating new game
@@ -45,6 +55,28 @@ type GameOptions struct {
 	BrowserMode bool
 	// Debug turns on debug mode if set to true
 	Debug bool
+	// Names holds custom names of players by their indexes, default names are used for other players
+	Names map[int]string
+	// Bots holds names of AI profiles by the indexes of players which are controlled by computer
+	Bots map[int]string
+	// Remotes holds indexes of players which are controlled from another process over the network
+	Remotes []int
+	// Colors holds custom colors of tanks by the indexes of players, default colors are used for other players
+	Colors map[int]tl.Attr
+	// Keys holds keys bound to the game commands, default keys are used if they are not set
+	Keys keys.Bindings
+	// Wind defines how the wind behaves during rounds
+	Wind entities.WindMode
+	// World holds values affecting physics and generation of the game world
+	World entities.Tunables
+	// Interest is rate in percents used to increase money left to the next round
+	Interest float64
+	// Client identifies that this game is only following the game hosted by another process.
+	// Rounds are not switched locally but only when host requests it.
+	Client bool
+	// Spectator identifies that this game is only watched, no key input will reach the controls.
+	// It's used together with Client.
+	Spectator bool
 }
 
 // NewGame creates new game object.
@@ -52,32 +84,73 @@ type GameOptions struct {
 func NewGame(o GameOptions) *Game {
 	game := &Game{}
 	game.options = o
+	if game.options.Keys.Global == nil {
+		game.options.Keys = keys.NewBindings(o.BrowserMode)
+	}
 
 	// init engine
 	game.engine = tl.NewGame()
 	game.engine.Screen().SetFps(float64(o.Fps))
 
+	// init events
+	game.events = &core.Events{}
+
 	// init debug
 	if o.Debug {
 		debug.Attach(game.engine)
+		game.events.Subscribe(logEvent)
 	}
 
 	// init players
 	game.players = make(core.Players, o.PlayerCount)
 	for pi := range game.players {
 		game.players[pi] = core.NewPlayer(fmt.Sprintf("Player %d", pi+1))
+		game.players[pi].Inventory = entities.NewInventory()
+		game.players[pi].Weapon = entities.BabyMissile.Name()
+	}
+	// options given for slots out of the game are ignored
+	for pi, profile := range o.Bots {
+		if pi >= o.PlayerCount {
+			continue
+		}
+		game.players[pi].Name = fmt.Sprintf("Computer %d", pi+1)
+		game.players[pi].Type = core.Computer
+		game.players[pi].Difficulty = profile
+	}
+	for _, pi := range o.Remotes {
+		if pi >= o.PlayerCount {
+			continue
+		}
+		game.players[pi].Type = core.Remote
+	}
+	for pi, name := range o.Names {
+		if pi >= o.PlayerCount {
+			continue
+		}
+		game.players[pi].Name = name
 	}
 
 	// init controls
 	game.controls = &Controls{game: game}
 	game.engine.Screen().AddEntity(game.controls)
 
+	// init clock
+	game.clock = NewClock(game)
+	game.engine.Screen().AddEntity(game.clock)
+
+	// init bot if there are some computer players
+	if len(o.Bots) > 0 {
+		game.bot = NewBot(game)
+	}
+
 	// init HUD
 	game.hud = hud.NewHUD(game, hud.Options{
-		ASCIIOnly:   o.ASCIIOnly,
-		LowColor:    o.LowColor,
-		BrowserMode: o.BrowserMode,
+		ASCIIOnly: o.ASCIIOnly,
+		LowColor:  o.LowColor,
+		Keys:      &game.options.Keys,
+		Wind:      o.Wind != entities.NoWind,
 	})
+	game.hud.Resize(o.Width, o.Height)
 	game.engine.Screen().AddEntity(game.hud)
 
 	// init round
@@ -91,8 +164,31 @@ func NewGame(o GameOptions) *Game {
 }
 
 // Start starts the game which means that game engine is started and first round is set up.
+// It returns when the game engine is stopped.
 func (g *Game) Start() {
 	g.engine.Start()
+	g.end()
+}
+
+// update advances the game simulation by one step and publishes all events happened during the step
+func (g *Game) update() {
+	g.controls.update()
+	if g.bot != nil {
+		g.bot.Update()
+	}
+	g.round.Update()
+	g.events.Publish()
+}
+
+// end publishes GameOver event
+func (g *Game) end() {
+	g.events.Push(core.GameOver{Players: g.players, Round: g.round.Model()})
+	g.events.Publish()
+}
+
+// Ticks returns number of simulation steps done since the start of the game
+func (g *Game) Ticks() int64 {
+	return g.clock.Ticks()
 }
 
 // InitialSeed returns seed used for the first level.
@@ -105,6 +201,27 @@ func (g *Game) LastSeed() int64 {
 	return g.options.Seed + int64(g.round.Number()-1)
 }
 
+// Options returns options used to create this game
+func (g *Game) Options() GameOptions {
+	return g.options
+}
+
+// Wind returns strength of the wind in the current round
+func (g *Game) Wind() int {
+	return g.round.world.Wind()
+}
+
+// ActiveTank returns tank which is on turn in the current round
+func (g *Game) ActiveTank() *core.Tank {
+	return g.round.model.ActiveTank()
+}
+
+// Events returns bus of all events happened in the game.
+// Use Subscribe to react on them.
+func (g *Game) Events() *core.Events {
+	return g.events
+}
+
 // Hud returns games HUD
 func (g *Game) Hud() *hud.HUD {
 	return g.hud
@@ -115,7 +232,50 @@ func (g *Game) Engine() *tl.Game {
 	return g.engine
 }
 
+// Controls returns game controls
+func (g *Game) Controls() *Controls {
+	return g.controls
+}
+
+// Round returns current game round
+func (g *Game) Round() *Round {
+	return g.round
+}
+
 // Players returns all players in the game
 func (g *Game) Players() core.Players {
 	return g.players
 }
+
+// HandToComputer gives control of the player with given index to the computer.
+// It allows to continue the game when remote player left.
+func (g *Game) HandToComputer(pi int) {
+	g.players[pi].Type = core.Computer
+	if g.bot == nil {
+		g.bot = NewBot(g)
+	}
+}
+
+// logEvent adds information about given event to debug logs
+func logEvent(e core.Event) {
+	switch e := e.(type) {
+	case core.RoundStarted:
+		debug.Logf("Round %d started", e.Round.Index+1)
+	case core.TurnStarted:
+		debug.Logf("Turn of %s started", e.Tank.Player.Name)
+	case core.ShotFired:
+		debug.Logf("Tank shooting weapon=%s angle=%d power=%f", e.Weapon, e.Tank.Angle, e.Tank.Power)
+	case core.ProjectileExploded:
+		debug.Logf("Projectile exploded weapon=%s x=%d y=%d", e.Projectile.Weapon, e.Position.X, e.Position.Y)
+	case core.TankDamaged:
+		debug.Logf("Tank of %s damaged damage=%d", e.Tank.Player.Name, e.Amount)
+	case core.ShieldDamaged:
+		debug.Logf("Shield of %s damaged damage=%d strength=%d", e.Tank.Player.Name, e.Amount, e.Tank.Shield.Strength)
+	case core.TankDied:
+		debug.Logf("Tank of %s died", e.Tank.Player.Name)
+	case core.RoundFinished:
+		debug.Logf("Round %d finished", e.Round.Index+1)
+	case core.GameOver:
+		debug.Logf("Game over after %d rounds", e.Round.Index+1)
+	}
+}
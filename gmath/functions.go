@@ -14,6 +14,12 @@ func Max(x, y int) int {
 	return int(math.Max(float64(x), float64(y)))
 }
 
+// Abs returns absolute value of x.
+// It's integer version of math.Abs.
+func Abs(x int) int {
+	return int(math.Abs(float64(x)))
+}
+
 // Clamp returns given value if it's in range given by min and max.
 // If value < min then min will be returned.
 // If value > max then max will be returned.
@@ -4,6 +4,7 @@ go 1.24.5
 
 require (
 	github.com/JoelOtter/termloop v0.0.0-20210806173944-5f7c38744afb
+	github.com/creack/pty v1.1.24
 	github.com/nsf/termbox-go v1.1.1
 	github.com/ojrac/opensimplex-go v1.0.2
 	github.com/pkg/errors v0.9.1
@@ -12,6 +13,7 @@ require (
 )
 
 require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.7 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
@@ -19,4 +21,5 @@ require (
 	github.com/xrash/smetrics v0.0.0-20250705151800-55b8f293f342 // indirect
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/term v0.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,118 @@
+package gorched
+
+import "github.com/zladovan/gorched/core"
+
+// Summary holds results of the game played in headless mode
+type Summary struct {
+	// InitialSeed is seed used for the first round
+	InitialSeed int64
+	// Ticks is number of simulation steps done during the whole game
+	Ticks int64
+	// Rounds holds results of each finished round
+	Rounds []RoundSummary
+	// Players holds results of each player collected during all finished rounds
+	Players []PlayerSummary
+}
+
+// RoundSummary holds results of one finished round
+type RoundSummary struct {
+	// Number is number of the round starting from 1
+	Number int
+	// Seed is seed used for generating the round
+	Seed int64
+	// Winner is name of the last player alive, it's empty if nobody survived
+	Winner string
+	// Ticks is number of simulation steps done since the start of the game until the round was finished
+	Ticks int64
+	// Players holds statistics of each player collected during the round
+	Players []PlayerSummary
+}
+
+// PlayerSummary holds statistics of one player
+type PlayerSummary struct {
+	// Name is the name of the player
+	Name string
+	// Difficulty is name of the AI profile used by computer player
+	Difficulty string `json:",omitempty"`
+	// Kills is number of killed enemies
+	Kills int
+	// Deaths is number of player's deaths
+	Deaths int
+	// Suicides is number of player's suicides
+	Suicides int
+	// Damage is number of hit points taken from enemies
+	Damage int
+}
+
+// newPlayerSummary creates summary for given player and statistics
+func newPlayerSummary(p *core.Player, s core.Stats) PlayerSummary {
+	return PlayerSummary{
+		Name:       p.Name,
+		Difficulty: p.Difficulty,
+		Kills:      s.Kills,
+		Deaths:     s.Deaths,
+		Suicides:   s.Suicides,
+		Damage:     s.Damage,
+	}
+}
+
+// RunHeadless plays the game without the screen as fast as possible until given number of rounds is finished.
+// It's expected that game is controlled only by computer players or by replaying of recorded game.
+// If some human player is in the game, game ends also when there are no more recorded events to replay and game is waiting for human input.
+func (g *Game) RunHeadless(rounds int) *Summary {
+	summary := &Summary{InitialSeed: g.options.Seed, Rounds: []RoundSummary{}}
+	finished := false
+	for len(summary.Rounds) < rounds {
+		if !g.controls.IsReplaying() && g.waitsForHuman() {
+			break
+		}
+		g.clock.Step()
+
+		// collect results right after the round is finished
+		if g.round.IsFinished() && !finished {
+			summary.Rounds = append(summary.Rounds, g.summarizeRound())
+		}
+		finished = g.round.IsFinished()
+	}
+
+	g.end()
+
+	summary.Ticks = g.Ticks()
+	for _, p := range g.players {
+		summary.Players = append(summary.Players, newPlayerSummary(p, p.Stats))
+	}
+	return summary
+}
+
+// summarizeRound returns results of the current round
+func (g *Game) summarizeRound() RoundSummary {
+	s := RoundSummary{
+		Number: g.round.Number(),
+		Seed:   g.LastSeed(),
+		Ticks:  g.Ticks(),
+	}
+	for pi, tank := range g.round.Tanks() {
+		player := g.players[pi]
+		if tank.IsAlive() {
+			s.Winner = player.Name
+		}
+		s.Players = append(s.Players, newPlayerSummary(player, tank.Stats()))
+	}
+	return s
+}
+
+// waitsForHuman returns true if game cannot continue without input from some human player.
+// It's when human player is on turn or when some form is shown and there is some human player to close it.
+func (g *Game) waitsForHuman() bool {
+	if g.round.IsPlayerOnTurn() && !g.round.ActiveTank().Player().IsComputer() {
+		return true
+	}
+	if g.hud.IsFormShown() {
+		for _, p := range g.players {
+			if !p.IsComputer() {
+				return true
+			}
+		}
+	}
+	return false
+}
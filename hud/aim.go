@@ -0,0 +1,122 @@
+package hud
+
+import (
+	"fmt"
+	"strings"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/hud/ui"
+)
+
+// layout of the aim form, placeholders are replaced with inputs, error message and buttons
+var aimLayout = Trim(`
+         ╔═╗┬┌┬┐
+         ╠═╣││││
+         ╩ ╩┴┴ ┴
+
+Angle  [   ]  0 - 180
+Power  [   ]  0 - %d
+%s
+
+| Press [Tab] to change focus.
+| Press [Enter] to shoot.
+
+                 Cancel Shoot
+`)
+
+// aimErrorWidth is number of cells reserved for the error message
+const aimErrorWidth = 28
+
+// AimForm allows to type exact angle of the cannon and shooting power and shoot with them.
+//
+// Values are validated after shooting is requested.
+// If some of them is not valid the error is shown and form stays opened.
+type AimForm struct {
+	*ui.BaseForm
+	// angle is input for the cannon's angle
+	angle *ui.Input
+	// power is input for the shooting power
+	power *ui.Input
+	// message shows the error when some value is not valid
+	message *ui.Text
+	// maxPower is maximal shooting power which can be used
+	maxPower int
+	// onShoot is called with typed values after form is closed
+	onShoot func(angle, power int)
+}
+
+// NewAimForm creates form with given initial angle and power.
+// Power can be from 0 to given maxPower.
+// Given onShoot is called with typed angle and power after the form is closed by shooting.
+func NewAimForm(angle, power, maxPower int, onShoot func(angle, power int)) *AimForm {
+	f := &AimForm{BaseForm: ui.NewForm(), maxPower: maxPower, onShoot: onShoot}
+
+	f.angle = ui.NewNumberInput(3, angle)
+	f.angle.FocusKeyChar = 'A'
+	f.power = ui.NewNumberInput(3, power)
+	f.power.FocusKeyChar = 'P'
+	inputs := []*ui.Input{f.angle, f.power}
+	f.message = ui.NewText(strings.Repeat(" ", aimErrorWidth))
+	f.message.Colors = ui.Colors{Fg: ui.ActivePallette.Highlight.Fg, Bg: ui.ActivePallette.Standard.Bg}
+
+	// container for all components
+	layout := fmt.Sprintf(aimLayout, maxPower, strings.Repeat("!", aimErrorWidth))
+	p := ui.NewFormatPane(layout, []*ui.ComponentBuilder{
+		{
+			Pattern: `\[\s{3}\]`,
+			Build: func(i int, s string) ui.Component {
+				return inputs[i]
+			},
+		},
+		{
+			Pattern: "!+",
+			Build: func(i int, s string) ui.Component {
+				return f.message
+			},
+		},
+		{
+			Pattern: "Cancel",
+			Build: func(i int, s string) ui.Component {
+				b := ui.NewButton("Cancel", func() { f.Close() })
+				b.ActionKey = 'C'
+				return b
+			},
+		},
+		{
+			Pattern: "Shoot",
+			Build: func(i int, s string) ui.Component {
+				b := ui.NewButton("Shoot", func() { f.Shoot() })
+				b.ActionKey = 'S'
+				return b
+			},
+		},
+	})
+	p.Style().CopyFrom(f.Style())
+	f.SetContainer(p)
+	return f
+}
+
+// Tick passes the event to the form, ENTER typed to some input shoots
+func (f *AimForm) Tick(e tl.Event) {
+	if _, ok := f.Focused().(*ui.Input); ok && e.Key == tl.KeyEnter {
+		f.Shoot()
+		return
+	}
+	f.BaseForm.Tick(e)
+}
+
+// Shoot validates typed values and if they are valid it closes the form and calls onShoot with them
+func (f *AimForm) Shoot() {
+	angle, ok := f.angle.Number()
+	if !ok || angle < 0 || angle > 180 {
+		f.message.SetText("Angle should be 0 - 180")
+		return
+	}
+	power, ok := f.power.Number()
+	if !ok || power < 0 || power > f.maxPower {
+		f.message.SetText(fmt.Sprintf("Power should be 0 - %d", f.maxPower))
+		return
+	}
+	f.Close()
+	f.onShoot(angle, power)
+}
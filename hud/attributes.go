@@ -17,6 +17,7 @@ Player 1
 Attack    [  1] + -     Explosion strength    [  1]
                         Shooting power        [100]
 Defense   [  1] + -     Armour                [100]
+Engine    [  1] + -     Fuel                  [ 20]
 
 Points    [  2]
 
@@ -81,9 +82,11 @@ func (f *AttributesForm) createPage(pageIndex int, player *core.Player) *ui.Base
 	power := ui.NewValue(player.Attributes.Power())
 	defense := ui.NewValue(player.Attributes.Defense)
 	armour := ui.NewValue(player.Attributes.Armour())
+	engine := ui.NewValue(player.Attributes.Engine)
+	fuel := ui.NewValue(player.Attributes.Fuel())
 	points := ui.NewValue(0)
 	points.Add(player.Attributes.Points)
-	attrs := []ui.Component{attack, explosion, power, defense, armour, points}
+	attrs := []ui.Component{attack, explosion, power, defense, armour, engine, fuel, points}
 
 	// plus / minus buttons
 	attackPlus := ui.NewButton("+", func() {
@@ -124,7 +127,25 @@ func (f *AttributesForm) createPage(pageIndex int, player *core.Player) *ui.Base
 			armour.Add(player.Attributes.Armour() - armour.Get())
 		}
 	})
-	buttons := []ui.Component{attackPlus, attackMinus, defensePlus, defenseMinus}
+	enginePlus := ui.NewButton("+", func() {
+		if points.Get() > 0 && engine.Get() < 100 {
+			engine.Add(1)
+			points.Add(-1)
+			player.Attributes.Engine++
+			player.Attributes.Points--
+			fuel.Add(player.Attributes.Fuel() - fuel.Get())
+		}
+	})
+	engineMinus := ui.NewButton("-", func() {
+		if engine.Addition() > 0 {
+			engine.Add(-1)
+			points.Add(1)
+			player.Attributes.Engine--
+			player.Attributes.Points++
+			fuel.Add(player.Attributes.Fuel() - fuel.Get())
+		}
+	})
+	buttons := []ui.Component{attackPlus, attackMinus, defensePlus, defenseMinus, enginePlus, engineMinus}
 
 	// container for all components
 	p := ui.NewFormatPane(attributesPageLayout, []*ui.ComponentBuilder{
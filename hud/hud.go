@@ -3,7 +3,9 @@ package hud
 import (
 	tl "github.com/JoelOtter/termloop"
 	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/gmath"
 	"github.com/zladovan/gorched/hud/ui"
+	"github.com/zladovan/gorched/keys"
 )
 
 // HUD stands for heads-up display and it holds entities which are drawn on  the screen always over all the level entities.
@@ -15,9 +17,12 @@ type HUD struct {
 	// form holds some ui form which can be displayed in the center of the screen
 	// there is always only one form shown at the same time
 	form ui.Form
-	// skipTick if true will cause Tick not processed until next frame redrawn
-	// this is needed to avoid closing message boxes right after their are shown
-	skipTick bool
+	// wind shows strength and direction of the wind
+	wind *WindIndicator
+	// shield shows remaining strength of the shield of the tank on turn
+	shield *ShieldIndicator
+	// screen holds the last known size of the screen, it's used to find form clicked by the mouse
+	screen gmath.Vector2i
 }
 
 // Options holds flags affecting how HUD should look like
@@ -26,8 +31,10 @@ type Options struct {
 	ASCIIOnly bool
 	// LowColor identifies that only 8 colors can be used for HUD graphics
 	LowColor bool
-	// BrowserMode identifies that game was run in browser and some controls need to be modified to do not collide with usual browser shortcuts
-	BrowserMode bool
+	// Keys holds keys bound to the game commands, they are shown in the help and changed by the settings form
+	Keys *keys.Bindings
+	// Wind if true will show wind indicator
+	Wind bool
 }
 
 // NewHUD creates new HUD for given game
@@ -36,7 +43,11 @@ func NewHUD(game core.Game, options Options) *HUD {
 	if options.LowColor {
 		ui.ActivePallette = ui.LowColorPallette
 	}
-	return &HUD{game: game, options: options}
+	h := &HUD{game: game, options: options, shield: &ShieldIndicator{game: game}}
+	if options.Wind {
+		h.wind = &WindIndicator{game: game, asciiOnly: options.ASCIIOnly}
+	}
+	return h
 }
 
 // ActiveForm returns currently shown form
@@ -56,7 +67,6 @@ func (h *HUD) ShowForm(box ui.Form) {
 		h.form = nil
 	})
 	h.form = box
-	h.skipTick = true
 }
 
 // HideForm hides any visible message box on the screen
@@ -73,7 +83,7 @@ func (h *HUD) HideForm() {
 
 // ShowInfo shows message box with main game information
 func (h *HUD) ShowInfo() *ui.MessageBox {
-	info := NewInfoBox(h.options.BrowserMode)
+	info := NewInfoBox(h.options.Keys, h.game.Players())
 	h.ShowForm(info)
 	return info
 }
@@ -93,6 +103,43 @@ func (h *HUD) ShowAttributes(readOnly bool) *AttributesForm {
 	return form
 }
 
+// ShowShop shows form where players can buy given goods.
+// Remote players are shopping here too as they are not able to shop in their own processes.
+// Computer players are shopping on their own.
+func (h *HUD) ShowShop(goods []core.Goods) *ShopForm {
+	form := NewShopForm(h.game.Players().Humans(), goods)
+	h.ShowForm(form)
+	return form
+}
+
+// ShowMenu shows menu with given items
+func (h *HUD) ShowMenu(items []MenuItem) *MenuForm {
+	form := NewMenuForm(items)
+	h.ShowForm(form)
+	return form
+}
+
+// ShowAim shows form for typing exact angle and power, given onShoot is called with them when player shoots
+func (h *HUD) ShowAim(angle, power, maxPower int, onShoot func(angle, power int)) *AimForm {
+	form := NewAimForm(angle, power, maxPower, onShoot)
+	h.ShowForm(form)
+	return form
+}
+
+// ShowSettings shows form for changing key bindings
+func (h *HUD) ShowSettings() *SettingsForm {
+	form := NewSettingsForm(h.options.Keys, h.game.Players())
+	h.ShowForm(form)
+	return form
+}
+
+// ShowMessage shows message box with given message
+func (h *HUD) ShowMessage(msg string) *ui.MessageBox {
+	box := ui.NewMessageBox(msg)
+	h.ShowForm(box)
+	return box
+}
+
 // MoveFocus moves focus to next component on currently opened form.
 // If no form is opened ignore it.
 func (h *HUD) MoveFocus() {
@@ -114,12 +161,21 @@ func (h *HUD) PressButton() {
 	})
 }
 
+// Resize changes the known size of the screen.
+// It's needed only when HUD is not drawn, otherwise the size is taken from the screen while drawing.
+func (h *HUD) Resize(width, height int) {
+	h.screen = gmath.Vector2i{X: width, Y: height}
+}
+
 // Draw draws all entities of HUD
 func (h *HUD) Draw(s *tl.Screen) {
-	// reset skipTick flag if needed
-	if h.skipTick {
-		h.skipTick = false
+	h.Resize(s.Size())
+	// wind indicator is always visible
+	if h.wind != nil {
+		h.wind.Draw(s)
 	}
+	// shield indicator is visible only when the tank on turn has some shield
+	h.shield.Draw(s)
 	// no form means nothing to draw now
 	if h.form == nil {
 		return
@@ -128,13 +184,18 @@ func (h *HUD) Draw(s *tl.Screen) {
 	h.form.Draw(s)
 }
 
-// Tick does nothing now
-func (h *HUD) Tick(e tl.Event) {
-	// prevents auto closing of message boxes
-	if h.skipTick {
+// Tick does nothing now, input events are passed to the form by Input
+func (h *HUD) Tick(e tl.Event) {}
+
+// Input passes given input event to currently opened form.
+// Form is placed on the screen before mouse events are passed to know which component is clicked.
+// If no form is opened ignore it.
+func (h *HUD) Input(e tl.Event) {
+	if h.form == nil {
 		return
 	}
-	if h.form != nil {
-		h.form.Tick(e)
+	if e.Type == tl.EventMouse {
+		h.form.Place(h.screen.X, h.screen.Y)
 	}
+	h.form.Tick(e)
 }
@@ -1,36 +1,104 @@
 package hud
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/hud/ui"
+	"github.com/zladovan/gorched/keys"
 )
 
-// text of info message box
-var infoText = Trim(`
-               ╔═╗╔═╗┬─┐┌─┐┬ ┬┌─┐┌┬┐              
-               ║ ╦║ ║├┬┘│  ├─┤├┤  ││              
-               ╚═╝╚═╝┴└─└─┘┴ ┴└─┘─┴┘              
-                                            
-Left / Right   change cannon angle                
-SPACE          start loading (1st) and shoot (2nd)
-Ctrl+C         exit game                          
-Ctrl+R         restart current round              
-Ctrl+N         start next round                   
-  S            show score       
-  A            show player's attributes
-  H            show help                          
-                                            
-                 © 2020, Zladovan                 
+// header of info message box
+var infoHeader = Trim(`
+╔═╗╔═╗┬─┐┌─┐┬ ┬┌─┐┌┬┐
+║ ╦║ ║├┬┘│  ├─┤├┤  ││
+╚═╝╚═╝┴└─└─┘┴ ┴└─┘─┴┘
 `)
 
-// NewInfoBox creates MessageBox with main game info
-func NewInfoBox(browserMode bool) *ui.MessageBox {
-	text := infoText
-	// in browser mode some controls are different to do not collide with browser shortcuts
-	if browserMode {
-		text = strings.ReplaceAll(text, "Ctrl+R", "  R   ")
-		text = strings.ReplaceAll(text, "Ctrl+N", "  N   ")
+// footer of info message box
+const infoFooter = "© 2020, Zladovan"
+
+// infoRow is one line of the help with keys and description of the command
+type infoRow struct {
+	// keys are names of the keys
+	keys string
+	// description explains what the keys do
+	description string
+}
+
+// NewInfoBox creates MessageBox with main game info.
+// It shows keys bound to all commands and own keys of the players who have some.
+func NewInfoBox(bindings *keys.Bindings, players core.Players) *ui.MessageBox {
+	rows := []infoRow{}
+	fixed := []infoRow{
+		{keys: "Mouse", description: "aim, hold to load and release to shoot"},
+		{keys: "Ctrl+C", description: "exit game"},
+	}
+	for _, info := range keys.Commands {
+		// mouse and exit can not be changed but they are shown right after commands controlling the tank
+		if !info.Player && fixed != nil {
+			rows = append(rows, fixed...)
+			fixed = nil
+		}
+		rows = append(rows, infoRow{keys: keyNames(bindings.Keys(-1, info.Command), " / "), description: info.Description})
+	}
+	for pi, player := range players {
+		if len(bindings.Players[pi]) == 0 {
+			continue
+		}
+		rows = append(rows, infoRow{}, infoRow{keys: player.Name + ":"})
+		for _, info := range keys.Commands {
+			if info.Player {
+				rows = append(rows, infoRow{keys: keyNames(bindings.Keys(pi, info.Command), " / "), description: info.Description})
+			}
+		}
+	}
+
+	// keys are aligned to one column
+	keysWidth := 0
+	for _, row := range rows {
+		if len(row.keys) > keysWidth && row.description != "" {
+			keysWidth = len(row.keys)
+		}
+	}
+	lines := []string{}
+	for _, row := range rows {
+		line := row.keys
+		if row.description != "" {
+			line = fmt.Sprintf("%-*s  %s", keysWidth, row.keys, row.description)
+		}
+		lines = append(lines, line)
+	}
+
+	// header and footer are centered
+	width := 0
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	text := &strings.Builder{}
+	for _, line := range strings.Split(infoHeader, "\n") {
+		fmt.Fprintln(text, Center(line, width))
+	}
+	fmt.Fprintln(text)
+	for _, line := range lines {
+		fmt.Fprintln(text, line)
+	}
+	fmt.Fprintln(text)
+	fmt.Fprint(text, Center(infoFooter, width))
+	return ui.NewMessageBox(text.String())
+}
+
+// keyNames returns names of given keys joined by given separator, it returns "none" if there are no keys
+func keyNames(ks []keys.Key, separator string) string {
+	if len(ks) == 0 {
+		return "none"
+	}
+	names := make([]string, len(ks))
+	for i, k := range ks {
+		names[i] = k.String()
 	}
-	return ui.NewMessageBox(text)
+	return strings.Join(names, separator)
 }
@@ -0,0 +1,69 @@
+package hud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zladovan/gorched/hud/ui"
+)
+
+// header of the menu, it expects menu items to follow
+var menuHeader = Trim(`
+  ╔╦╗┌─┐┌┐┌┬ ┬
+  ║║║├┤ ││││ │
+  ╩ ╩└─┘┘└┘└─┘
+`)
+
+// menuWidth is number of cells used to center menu items
+const menuWidth = 16
+
+// MenuItem is one action which can be chosen from the menu
+type MenuItem struct {
+	// Title is text of the item's button, it should be unique in the menu
+	Title string
+	// Key is character which will choose this item, it should be contained in the Title
+	Key rune
+	// Action is called after the item is chosen and menu is closed
+	Action func()
+}
+
+// MenuForm shows buttons for the game actions like saving the game or restarting the round.
+// Menu is closed right before the action of chosen item is called.
+type MenuForm struct {
+	*ui.BaseForm
+}
+
+// NewMenuForm creates new menu with one button for each of given items
+func NewMenuForm(items []MenuItem) *MenuForm {
+	f := &MenuForm{BaseForm: ui.NewForm()}
+
+	// layout with one line per item
+	layout := &strings.Builder{}
+	fmt.Fprint(layout, menuHeader)
+	fmt.Fprintln(layout)
+	builders := []*ui.ComponentBuilder{}
+	for _, item := range items {
+		item := item
+		fmt.Fprintln(layout)
+		fmt.Fprintf(layout, "%*s", (menuWidth-len(item.Title))/2+len(item.Title), item.Title)
+		builders = append(builders, &ui.ComponentBuilder{
+			Pattern: regexp.QuoteMeta(item.Title),
+			Build: func(i int, s string) ui.Component {
+				b := ui.NewButton(item.Title, func() {
+					f.Close()
+					item.Action()
+				})
+				b.ActionKey = item.Key
+				return b
+			},
+		})
+	}
+	fmt.Fprintln(layout)
+
+	// container for all components
+	p := ui.NewFormatPane(layout.String(), builders)
+	p.Style().CopyFrom(f.Style())
+	f.SetContainer(p)
+	return f
+}
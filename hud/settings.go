@@ -0,0 +1,246 @@
+package hud
+
+import (
+	"fmt"
+	"strings"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/gmath"
+	"github.com/zladovan/gorched/hud/ui"
+	"github.com/zladovan/gorched/keys"
+)
+
+// header of the settings form
+var settingsHeader = Trim(`
+╔═╗┌─┐┌┬┐┌┬┐┬┌┐┌┌─┐┌─┐
+╚═╗├┤  │  │ │││││ ┬└─┐
+╚═╝└─┘ ┴  ┴ ┴┘└┘└─┘└─┘
+`)
+
+// hints shown at the bottom of the settings form
+var settingsHints = Trim(`
+| Press [Tab] to change focus.
+| Press [Enter] and then the new key to change it.
+`)
+
+// navigation buttons shown at the bottom of the settings form
+const settingsNavigation = "Previous Reset Next Finish"
+
+// settingsKeysWidth is number of cells used to show keys of one command
+const settingsKeysWidth = 18
+
+// SettingsForm allows to change keys bound to the game commands.
+//
+// It contains the page with keys for all players.
+// When there are more local human players there is also one page for each of them with their own keys.
+// Own keys of the player can be reset back to the keys for all players.
+//
+// Each command has a button showing it's keys.
+// After the button is pressed the next pressed key is bound to the command instead of actual keys.
+type SettingsForm struct {
+	*ui.BaseForm
+	// bindings holds keys which are shown and changed
+	bindings *keys.Bindings
+	// pages holds page with keys for all players and pages with own keys of the players
+	pages []*settingsPage
+	// activePage is index of currently visible page
+	activePage int
+	// capture is called with the next key event instead of passing it to the form, it's nil when no key is awaited
+	capture func(e tl.Event)
+}
+
+// settingsPage is one page of the settings form
+type settingsPage struct {
+	// container holds all components of the page
+	container ui.Container
+	// player is index of the player whose keys are on this page, it's negative for the page with keys for all players
+	player int
+	// commands holds commands shown on this page
+	commands []keys.Command
+	// buttons holds button showing keys for each of commands
+	buttons []*ui.Button
+}
+
+// NewSettingsForm creates new form for changing given key bindings of given players
+func NewSettingsForm(bindings *keys.Bindings, players core.Players) *SettingsForm {
+	f := &SettingsForm{BaseForm: ui.NewForm(), bindings: bindings}
+
+	// own keys are shown only when there are more players playing on the same keyboard
+	owners := []int{-1}
+	humans := []int{}
+	for pi, player := range players {
+		if player.Type == core.Human {
+			humans = append(humans, pi)
+		}
+	}
+	if len(humans) > 1 {
+		owners = append(owners, humans...)
+	}
+
+	f.pages = make([]*settingsPage, len(owners))
+	for i, pi := range owners {
+		title := "All players"
+		if pi >= 0 {
+			title = players[pi].Name
+		}
+		f.pages[i] = f.createPage(i, len(owners), pi, title)
+	}
+	f.SetContainer(f.pages[0].container)
+	return f
+}
+
+// createPage creates page with keys of the player with given index.
+// Negative index is used for the page with keys for all players.
+func (f *SettingsForm) createPage(pageIndex, pageCount, player int, title string) *settingsPage {
+	page := &settingsPage{player: player}
+
+	// one line for each command with the placeholder for the button with keys
+	descriptionWidth := 0
+	for _, info := range keys.Commands {
+		if len(info.Description) > descriptionWidth {
+			descriptionWidth = len(info.Description)
+		}
+	}
+	placeholder := "[" + strings.Repeat(" ", settingsKeysWidth) + "]"
+	width := descriptionWidth + 2 + len(placeholder)
+	layout := &strings.Builder{}
+	for _, line := range strings.Split(settingsHeader, "\n") {
+		fmt.Fprintln(layout, Center(line, width))
+	}
+	fmt.Fprintln(layout)
+	fmt.Fprintln(layout, title)
+	fmt.Fprintln(layout)
+	for _, info := range keys.Commands {
+		if player >= 0 && !info.Player {
+			continue
+		}
+		page.commands = append(page.commands, info.Command)
+		fmt.Fprintf(layout, "%-*s  %s\n", descriptionWidth, info.Description, placeholder)
+	}
+	fmt.Fprintln(layout)
+	fmt.Fprintln(layout, settingsHints)
+	fmt.Fprintln(layout)
+	fmt.Fprintf(layout, "%*s", width, settingsNavigation)
+
+	page.buttons = make([]*ui.Button, len(page.commands))
+	page.container = ui.NewFormatPane(layout.String(), []*ui.ComponentBuilder{
+		{
+			Pattern: `\[ +\]`,
+			Build: func(i int, s string) ui.Component {
+				command := page.commands[i]
+				b := ui.NewButton(f.keysText(player, command), nil)
+				b.Action = func() { f.captureKey(page, command, b) }
+				page.buttons[i] = b
+				return b
+			},
+		},
+		{
+			Pattern: "Previous",
+			Skip:    pageIndex == 0,
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Previous", func() { f.Previous() })
+				b.ActionKey = 'P'
+				return b
+			},
+		},
+		{
+			Pattern: "Reset",
+			Skip:    player < 0,
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Reset", func() {
+					f.bindings.Reset(player)
+					f.refreshPage(page)
+				})
+				b.ActionKey = 'R'
+				return b
+			},
+		},
+		{
+			Pattern: "Next",
+			Skip:    pageIndex == pageCount-1,
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Next", func() { f.Next() })
+				b.ActionKey = 'N'
+				return b
+			},
+		},
+		{
+			Pattern: "Finish",
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Finish", func() { f.Close() })
+				b.ActionKey = 'F'
+				return b
+			},
+		},
+	})
+	page.container.Style().CopyFrom(f.Style())
+	return page
+}
+
+// Tick passes the key event to the form or binds pressed key to the command if some key is awaited
+func (f *SettingsForm) Tick(e tl.Event) {
+	if f.Closed() {
+		return
+	}
+	if f.capture != nil && e.Type == tl.EventKey {
+		capture := f.capture
+		f.capture = nil
+		capture(e)
+		return
+	}
+	f.BaseForm.Tick(e)
+}
+
+// captureKey waits for the next pressed key and binds it to given command on given page
+func (f *SettingsForm) captureKey(page *settingsPage, command keys.Command, b *ui.Button) {
+	b.Text = fmt.Sprintf("[%-*s]", settingsKeysWidth, "press new key")
+	b.Refresh()
+	f.capture = func(e tl.Event) {
+		if page.player < 0 {
+			f.bindings.Global.Bind(command, keys.FromEvent(e))
+		} else {
+			f.bindings.Player(page.player).Bind(command, keys.FromEvent(e))
+		}
+		f.refreshPage(page)
+	}
+}
+
+// keysText returns text of the button with keys bound to given command for the player with given index
+func (f *SettingsForm) keysText(player int, command keys.Command) string {
+	text := keyNames(f.bindings.Keys(player, command), ", ")
+	if len(text) > settingsKeysWidth {
+		text = text[:settingsKeysWidth]
+	}
+	return fmt.Sprintf("[%-*s]", settingsKeysWidth, text)
+}
+
+// refreshPage updates texts of all buttons with keys on given page
+func (f *SettingsForm) refreshPage(page *settingsPage) {
+	for i, b := range page.buttons {
+		b.Text = f.keysText(page.player, page.commands[i])
+		b.Refresh()
+	}
+}
+
+// Next changes active page to the next page of the form
+func (f *SettingsForm) Next() {
+	f.changePage(1)
+}
+
+// Previous changes active page to the previous page of the form
+func (f *SettingsForm) Previous() {
+	f.changePage(-1)
+}
+
+// changePage adds given change to activePage index and switch form's container according it.
+// Keys on the new page are refreshed as they could be changed on other pages.
+func (f *SettingsForm) changePage(change int) {
+	next := gmath.Max(0, gmath.Min(len(f.pages)-1, f.activePage+change))
+	if next == f.activePage {
+		return
+	}
+	f.activePage = next
+	f.refreshPage(f.pages[next])
+	f.SetContainer(f.pages[next].container)
+}
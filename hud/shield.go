@@ -0,0 +1,40 @@
+package hud
+
+import (
+	"fmt"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/hud/ui"
+)
+
+// ShieldIndicator shows remaining strength of the shield of the tank on turn.
+// It's drawn in the top right corner of the screen under the wind indicator only when the tank has some shield.
+//
+// It looks like this:
+//
+// " Force Field 45 "
+type ShieldIndicator struct {
+	// game is used to get the tank on turn
+	game core.Game
+}
+
+// Draw draws indicator to the top right corner of the screen
+func (i *ShieldIndicator) Draw(s *tl.Screen) {
+	tank := i.game.ActiveTank()
+	if tank == nil || !tank.Shield.IsActive() {
+		return
+	}
+
+	// draw text
+	text := []rune(fmt.Sprintf(" %s %d ", tank.Shield.Name, tank.Shield.Strength))
+	sw, _ := s.Size()
+	colors := ui.ActivePallette.Standard
+	x := sw - len(text) - 1
+	for j, ch := range text {
+		s.RenderCell(x+j, 1, &tl.Cell{Fg: colors.Fg | tl.AttrBold, Bg: colors.Bg, Ch: ch})
+	}
+}
+
+// Tick does nothing now
+func (i *ShieldIndicator) Tick(e tl.Event) {}
@@ -0,0 +1,208 @@
+package hud
+
+import (
+	"fmt"
+	"strings"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/gmath"
+	"github.com/zladovan/gorched/hud/ui"
+)
+
+// header of the shop page, it expects goods rows to follow and number of the goods page with count of goods pages
+var shopPageHeader = Trim(`
+                  ╔═╗┬ ┬┌─┐┌─┐
+                  ╚═╗├─┤│ │├─┘
+                  ╚═╝┴ ┴└─┘┴
+
+Player 1                           Money [    0]
+
+Goods %d/%d             Price     Owned
+`)
+
+// shopPageGoods is maximal number of goods rows on one page, it keeps the form small enough for 24 rows terminal
+const shopPageGoods = 6
+
+// format string used for each goods row, expects goods name and amount
+var shopPageRow = "%-14s %2d for [  0]     [  0]   + -"
+
+// footer of the shop page
+var shopPageFooter = Trim(`
+
+| Press [Tab] to change focus.
+| Press [Enter] to do action.
+
+                           Previous Next Finish
+`)
+
+// ShopForm allows players to spend their money for weapons and items.
+//
+// It contains multiple pages for each player, goods are split to more pages to fit small terminals.
+// There are also buttons for navigation between pages.
+//
+// Each page shows player's money and all goods with their prices and owned amounts.
+// Goods can be bought with + button.
+// Goods bought in this form can be returned with - button.
+type ShopForm struct {
+	*ui.BaseForm
+	// players holds players who are shopping
+	players core.Players
+	// goods holds everything what can be bought
+	goods []core.Goods
+	// activePage is index of currently visible page
+	activePage int
+	// pages hold containers for all players, each player has one container for each part of goods
+	pages []ui.Container
+}
+
+// NewShopForm creates new form where given players can buy given goods
+func NewShopForm(players core.Players, goods []core.Goods) *ShopForm {
+	f := &ShopForm{
+		BaseForm: ui.NewForm(),
+		players:  players,
+		goods:    goods,
+	}
+	f.initPages()
+	return f
+}
+
+// initPages creates containers for each player
+func (f *ShopForm) initPages() {
+	count := gmath.Max(1, (len(f.goods)+shopPageGoods-1)/shopPageGoods)
+	f.pages = make([]ui.Container, 0, len(f.players)*count)
+	for _, player := range f.players {
+		// money is shown on each page of the player so all of them are changed when player buys something
+		moneys := make([]*ui.Value, count)
+		for i := range moneys {
+			moneys[i] = ui.NewValue(player.Money)
+			moneys[i].Digits = 5
+		}
+		for i := 0; i < count; i++ {
+			part := f.goods[i*shopPageGoods : gmath.Min(len(f.goods), (i+1)*shopPageGoods)]
+			f.pages = append(f.pages, f.createPage(len(f.pages), player, moneys, i, count, part))
+		}
+	}
+	if len(f.pages) > 0 {
+		f.SetContainer(f.pages[0])
+	}
+}
+
+// createPage creates one container with all components for one player and given part of goods.
+// Money of the player is shown by the value on given index of moneys, all of them are changed when money is spent.
+func (f *ShopForm) createPage(pageIndex int, player *core.Player, moneys []*ui.Value, part, parts int, goods []core.Goods) *ui.BaseContainer {
+	// label with player name
+	name := ui.NewText(player.Name)
+	name.Colors.Fg = ui.ActivePallette.Standard.Fg | tl.AttrBold
+
+	// player's money
+	addMoney := func(x int) {
+		for _, m := range moneys {
+			m.Add(x)
+		}
+	}
+
+	// layout with one row per goods
+	layout := &strings.Builder{}
+	fmt.Fprintf(layout, shopPageHeader, part+1, parts)
+
+	// prices, owned amounts and buttons for each goods
+	values := []ui.Component{moneys[part]}
+	buttons := []ui.Component{}
+	for _, g := range goods {
+		g := g
+		fmt.Fprintln(layout)
+		fmt.Fprintf(layout, shopPageRow, g.Name, g.Amount)
+
+		owned := ui.NewValue(player.Inventory.Ammo(g.Name))
+		values = append(values, ui.NewValue(g.Price), owned)
+
+		buy := ui.NewButton("+", func() {
+			if player.Buy(g) {
+				addMoney(-g.Price)
+				owned.Add(g.Amount)
+			}
+		})
+		sell := ui.NewButton("-", func() {
+			if owned.Addition() >= g.Amount && player.Sell(g) {
+				addMoney(g.Price)
+				owned.Add(-g.Amount)
+			}
+		})
+		buttons = append(buttons, buy, sell)
+	}
+
+	// empty rows keep the same size of all pages
+	fmt.Fprint(layout, strings.Repeat("\n", shopPageGoods-len(goods)+1))
+	fmt.Fprint(layout, shopPageFooter)
+
+	// container for all components
+	p := ui.NewFormatPane(layout.String(), []*ui.ComponentBuilder{
+		{
+			Pattern: `Player \d`,
+			Build: func(i int, s string) ui.Component {
+				return name
+			},
+		},
+		{
+			Pattern: `\[\s*\d+\]`,
+			Build: func(i int, s string) ui.Component {
+				return values[i]
+			},
+		},
+		{
+			Pattern: `[\\+\\-]`,
+			Build: func(i int, s string) ui.Component {
+				return buttons[i]
+			},
+		},
+		{
+			Pattern: "Next",
+			Skip:    pageIndex == len(f.players)*parts-1,
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Next", func() { f.Next() })
+				b.ActionKey = 'N'
+				return b
+			},
+		},
+		{
+			Pattern: "Finish",
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Finish", func() { f.Close() })
+				b.ActionKey = 'F'
+				return b
+			},
+		},
+		{
+			Pattern: "Previous",
+			Skip:    pageIndex == 0,
+			Build: func(i int, str string) ui.Component {
+				b := ui.NewButton("Previous", func() { f.Previous() })
+				b.ActionKey = 'P'
+				return b
+			},
+		},
+	})
+	p.Style().CopyFrom(f.Style())
+	return p
+}
+
+// Next changes active page to the next page of the form
+func (f *ShopForm) Next() {
+	f.changePage(1)
+}
+
+// Previous changes active page to the previous page of the form
+func (f *ShopForm) Previous() {
+	f.changePage(-1)
+}
+
+// changePage adds given change to activePage index and switch form's container according it
+func (f *ShopForm) changePage(change int) {
+	next := gmath.Clamp(0, len(f.pages)-1, f.activePage+change)
+	if next == f.activePage {
+		return
+	}
+	f.activePage = next
+	f.SetContainer(f.pages[f.activePage])
+}
@@ -1,10 +1,22 @@
 package hud
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
 
 // Trim will remove leading and trailing empty line
 func Trim(s string) string {
 	s = strings.TrimPrefix(s, "\n")
 	s = strings.TrimSuffix(s, "\n")
 	return s
-}
\ No newline at end of file
+}
+
+// Center will pad given line from the left to be in the center of given width
+func Center(line string, width int) string {
+	length := utf8.RuneCountInString(line)
+	if length >= width {
+		return line
+	}
+	return strings.Repeat(" ", (width-length)/2) + line
+}
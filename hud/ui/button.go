@@ -17,6 +17,7 @@ import (
 //
 //  - it has focus and SPACE or ENTER is hit
 //  - it has ActionKey defined and it was hit
+//  - it was clicked by the mouse
 //
 // To create new button call NewButton.
 // Then set button's attributes and add it to some container.
@@ -96,6 +97,11 @@ func (b *Button) Tick(e tl.Event) {
 	}
 }
 
+// Click calls button's action, button has focus already when it's clicked
+func (b *Button) Click() {
+	b.Action()
+}
+
 // FocusKey defines character which when typed should bring focus to this button
 func (b *Button) FocusKey() rune {
 	return b.ActionKey
@@ -124,5 +130,5 @@ func (b *Button) highlightActionKey(canvas *tl.Canvas) {
 	if ai == -1 {
 		return
 	}
-	(*canvas)[0][ai].Fg |= tl.AttrUnderline
+	(*canvas)[ai][0].Fg |= tl.AttrUnderline
 }
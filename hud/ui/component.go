@@ -36,6 +36,21 @@ type Focuser interface {
 	Tick(e tl.Event)
 }
 
+// Clicker should be used to extend some component with possibility to be clicked by the mouse.
+// Form will move focus to the clicked component before it's clicked.
+type Clicker interface {
+	// Click is called when left mouse button was pressed over the component
+	Click()
+}
+
+// Editor is focuser which accepts typed characters, e.g. text input.
+// Form does not move focus by focus keys when character typed to focused Editor is accepted by it.
+type Editor interface {
+	Focuser
+	// Accepts returns true if given character can be typed to this editor
+	Accepts(ch rune) bool
+}
+
 // Parent holds one or more child components.
 // It provides support for creating hierarchy of components.
 // But Parent itself does not need to be component.
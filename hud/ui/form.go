@@ -23,6 +23,9 @@ type Form interface {
 	// NextFocus moves focus to next component
 	NextFocus()
 	// TODO: move NextFocus to separate interface ?
+	// Place moves form to the center of the screen with given size.
+	// It should be called before mouse events are passed to the form to know which component is clicked.
+	Place(screenWidth, screenHeight int)
 }
 
 // BaseForm is basic implementation of form with BaseContainer.
@@ -31,6 +34,8 @@ type Form interface {
 // Pressing `Tab` will move focus to next component.
 // Components are in the same order as they were added to the form.
 // Additionally form listens for one of focus keys which can be defined by components.
+// Focus keys are ignored when they are typed to the focused Editor which accepts them.
+// Focus is also moved by clicking on the component with the left mouse button, clicked Clicker is then clicked.
 // Focus management does not support nested containers yet !
 //
 // You can use it directly to show some components.
@@ -71,14 +76,19 @@ func (f *BaseForm) Draw(s *tl.Screen) {
 	if f.closed {
 		return
 	}
+	// redraw to canvas when needed
+	f.Place(s.Size())
+	f.entity.Draw(s)
+}
+
+// Place moves form to the center of the screen with given size
+func (f *BaseForm) Place(screenWidth, screenHeight int) {
 	// redraw to canvas when needed
 	if !f.refreshed {
 		f.Refresh()
 	}
 	w, h := f.entity.Size()
-	sw, sh := s.Size()
-	f.entity.SetPosition(sw/2-w/2, sh/2-h/2)
-	f.entity.Draw(s)
+	f.entity.SetPosition(screenWidth/2-w/2, screenHeight/2-h/2)
 }
 
 // Refresh redraws form to it's canvas
@@ -113,13 +123,21 @@ func (f *BaseForm) Tick(e tl.Event) {
 		return
 	}
 
+	// mouse is used only for clicking
+	if e.Type == tl.EventMouse {
+		if e.Key == tl.MouseLeft {
+			f.click(e.MouseX, e.MouseY)
+		}
+		return
+	}
+
 	// move focus index
 	if e.Key == tl.KeyTab {
 		f.NextFocus()
 	}
 
-	// process focus keys
-	if e.Ch != 0 {
+	// process focus keys unless they are typed to the focused editor
+	if e.Ch != 0 && !f.isEditing(e.Ch) {
 		for fi, focuser := range f.focusers {
 			if unicode.ToLower(e.Ch) == unicode.ToLower(focuser.FocusKey()) {
 				f.setFocusIndex(fi)
@@ -202,6 +220,46 @@ func (f *BaseForm) OnClose(fn func()) {
 	f.onClose = append(f.onClose, fn)
 }
 
+// Focused returns component which has focus now or nil if there is no component which can gain focus
+func (f *BaseForm) Focused() Focuser {
+	if f.focusIndex >= len(f.focusers) {
+		return nil
+	}
+	return f.focusers[f.focusIndex]
+}
+
+// click moves focus to the component on given screen position and clicks it if it's Clicker
+func (f *BaseForm) click(x, y int) {
+	if f.entity == nil {
+		return
+	}
+	fx, fy := f.entity.Position()
+	x -= fx + f.Style().Padding.Left + f.Style().Border.Size
+	y -= fy + f.Style().Padding.Top + f.Style().Border.Size
+	for fi, focuser := range f.focusers {
+		c, ok := focuser.(Component)
+		if !ok {
+			continue
+		}
+		p := c.Position()
+		d := c.Dimensions()
+		if x < p.X || x >= p.X+d.X || y < p.Y || y >= p.Y+d.Y {
+			continue
+		}
+		f.setFocusIndex(fi)
+		if clicker, ok := focuser.(Clicker); ok {
+			clicker.Click()
+		}
+		return
+	}
+}
+
+// isEditing returns true if given character will be typed to the focused editor
+func (f *BaseForm) isEditing(ch rune) bool {
+	editor, ok := f.Focused().(Editor)
+	return ok && editor.Accepts(ch)
+}
+
 // setFocusIndex changes focus to component with index given by i
 func (f *BaseForm) setFocusIndex(i int) {
 	if f.focusIndex != i {
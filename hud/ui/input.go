@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/draw"
+	"github.com/zladovan/gorched/gmath"
+)
+
+// Input is component for typing short text.
+// It can gain focus and it receives typed characters while it has focus.
+//
+// Text is shown in the box with Width characters and more characters can not be typed.
+// First character typed after input gained focus replaces whole text.
+// Last character can be removed with BACKSPACE.
+// Only characters accepted by Filter can be typed, use NewNumberInput to accept only digits.
+type Input struct {
+	*BaseComponent
+	// Colors defines colors of the input, focus colors are used when input has focus
+	Colors ButtonColors
+	// Width is maximal number of characters
+	Width int
+	// FocusKeyChar is character which when typed this input will gain focus.
+	// It should not be accepted by the Filter otherwise it would be typed into the focused input.
+	FocusKeyChar rune
+	// Filter returns true for characters which can be typed, all printable characters are accepted when it's nil
+	Filter func(ch rune) bool
+	// OnChange is called after text was changed by typing, it's optional
+	OnChange func(text string)
+
+	// text holds typed characters
+	text []rune
+	// focus is flag defining if this input has focus now
+	focus bool
+	// fresh is true when input gained focus and nothing was typed yet
+	fresh bool
+}
+
+// NewInput creates input with given width and initial text
+func NewInput(width int, text string) *Input {
+	i := &Input{
+		BaseComponent: &BaseComponent{},
+		Colors: ButtonColors{
+			Standard: Colors{Fg: ActivePallette.Standard.Bg, Bg: ActivePallette.Standard.Fg},
+			Focus:    ActivePallette.Focus,
+		},
+		Width: width,
+	}
+	i.SetText(text)
+	return i
+}
+
+// NewNumberInput creates input with given width accepting only digits, it shows given initial value
+func NewNumberInput(width int, value int) *Input {
+	i := NewInput(width, strconv.Itoa(value))
+	i.Filter = unicode.IsDigit
+	return i
+}
+
+// Text returns typed text
+func (i *Input) Text() string {
+	return string(i.text)
+}
+
+// SetText changes typed text, it's cut to the Width of input
+func (i *Input) SetText(text string) {
+	i.text = []rune(text)
+	if len(i.text) > i.Width {
+		i.text = i.text[:i.Width]
+	}
+	i.Refresh()
+}
+
+// Number returns typed text as number, ok is false if text is not a number
+func (i *Input) Number() (n int, ok bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(i.Text()))
+	return n, err == nil
+}
+
+// Dimensions returns Width + 2 for the box borders as X and 1 as Y
+func (i *Input) Dimensions() gmath.Vector2i {
+	return gmath.Vector2i{X: i.Width + 2, Y: 1}
+}
+
+// GainFocus will add focus to this input
+func (i *Input) GainFocus() {
+	i.focus = true
+	i.fresh = true
+	i.Refresh()
+}
+
+// LooseFocus will remove focus from this input
+func (i *Input) LooseFocus() {
+	i.focus = false
+	i.Refresh()
+}
+
+// FocusKey defines character which when typed should bring focus to this input
+func (i *Input) FocusKey() rune {
+	return i.FocusKeyChar
+}
+
+// Accepts returns true if given character can be typed to this input
+func (i *Input) Accepts(ch rune) bool {
+	if i.Filter != nil {
+		return i.Filter(ch)
+	}
+	return unicode.IsPrint(ch)
+}
+
+// Tick handles typing when input has focus
+func (i *Input) Tick(e tl.Event) {
+	if !i.focus {
+		return
+	}
+	ch := e.Ch
+	switch e.Key {
+	case tl.KeyBackspace, tl.KeyBackspace2:
+		i.fresh = false
+		if len(i.text) > 0 {
+			i.change(i.text[:len(i.text)-1])
+		}
+		return
+	case tl.KeySpace:
+		ch = ' '
+	}
+	if ch == 0 || !i.Accepts(ch) {
+		return
+	}
+	if i.fresh {
+		i.fresh = false
+		i.change([]rune{ch})
+	} else if len(i.text) < i.Width {
+		i.change(append(i.text, ch))
+	}
+}
+
+// change changes typed text and lets know about it
+func (i *Input) change(text []rune) {
+	i.text = text
+	i.Refresh()
+	if i.OnChange != nil {
+		i.OnChange(i.Text())
+	}
+}
+
+// Refresh will redraw this input to it's canvas.
+// Cursor is shown after the text when input has focus.
+func (i *Input) Refresh() {
+	p := draw.BlankPrinter(i.Width+2, 1)
+	p.Fg = i.Colors.Standard.Fg
+	p.Bg = i.Colors.Standard.Bg
+	if i.focus {
+		p.Fg = i.Colors.Focus.Fg
+		p.Bg = i.Colors.Focus.Bg
+	}
+	text := string(i.text)
+	if i.focus && len(i.text) < i.Width {
+		text += "_"
+	}
+	p.Write(0, 0, "["+text+strings.Repeat(" ", i.Width-len([]rune(text)))+"]")
+	i.SetCanvas(p.Canvas)
+}
@@ -19,8 +19,8 @@ func NewMessageBox(msg string) *MessageBox {
 
 // Tick handles controls for this MessageBox
 func (m *MessageBox) Tick(e tl.Event) {
-	// Message box is closed on any key press
-	if e.Type == tl.EventKey {
+	// Message box is closed on any key press or mouse click
+	if e.Type == tl.EventKey || (e.Type == tl.EventMouse && e.Key == tl.MouseLeft) {
 		m.Close()
 	}
 }
@@ -30,6 +30,13 @@ func NewText(text string) *Text {
 	return NewTextFromLines(strings.Split(text, "\n"))
 }
 
+// SetText changes shown text and redraws this component.
+// Text can contain multiple lines.
+func (t *Text) SetText(text string) {
+	t.lines = strings.Split(text, "\n")
+	t.Refresh()
+}
+
 // Dimensions returns length of longest line as X and lines count as Y
 func (t *Text) Dimensions() gmath.Vector2i {
 	w := 0
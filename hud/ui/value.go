@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/zladovan/gorched/draw"
 	"github.com/zladovan/gorched/gmath"
@@ -18,7 +19,9 @@ import (
 type Value struct {
 	*BaseComponent
 	// Colors defines colors used to draw this component
-	Colors         ValueColors
+	Colors ValueColors
+	// Digits is number of digits which are visible, it's 3 by default
+	Digits         int
 	base, addition int
 }
 
@@ -40,23 +43,24 @@ func NewValue(base int) *Value {
 			Standard:  ActivePallette.Standard,
 			Highlight: ActivePallette.Highlight,
 		},
-		base: base,
+		Digits: 3,
+		base:   base,
 	}
 }
 
-// Dimensions return always X: 5 and Y: 1
+// Dimensions return X: Digits + 2 and Y: 1
 func (v *Value) Dimensions() gmath.Vector2i {
-	return gmath.Vector2i{X: 5, Y: 1}
+	return gmath.Vector2i{X: v.Digits + 2, Y: 1}
 }
 
 // Refresh redraws this component to it's canvas
 func (v *Value) Refresh() {
-	p := draw.BlankPrinter(5, 1)
+	p := draw.BlankPrinter(v.Digits+2, 1)
 
 	// draw box for value, colors are inverted
 	p.Bg = v.Colors.Standard.Fg
 	p.Fg = v.Colors.Standard.Bg
-	p.Write(0, 0, "[   ]")
+	p.Write(0, 0, "["+strings.Repeat(" ", v.Digits)+"]")
 
 	// change colors when value was changed and should be highlighted
 	if v.addition != 0 {
@@ -69,7 +73,7 @@ func (v *Value) Refresh() {
 	}
 
 	// print value
-	p.Write(1, 0, fmt.Sprintf("%3d", v.Get()))
+	p.Write(1, 0, fmt.Sprintf("%*d", v.Digits, v.Get()))
 
 	// change canvas
 	v.SetCanvas(p.Canvas)
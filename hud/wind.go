@@ -0,0 +1,59 @@
+package hud
+
+import (
+	"fmt"
+	"strings"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/gmath"
+	"github.com/zladovan/gorched/hud/ui"
+)
+
+// WindIndicator shows strength and direction of the wind in the top right corner of the screen.
+//
+// It looks like this:
+//
+// " Wind ◀◀ 5 "   wind is blowing to the left
+// " Wind 5 ▶▶ "   wind is blowing to the right
+type WindIndicator struct {
+	// game is used to get actual wind
+	game core.Game
+	// asciiOnly if true will use only ASCII characters for arrows
+	asciiOnly bool
+}
+
+// windPerArrow is strength of the wind represented by one arrow
+const windPerArrow = 4
+
+// Draw draws indicator to the top right corner of the screen
+func (w *WindIndicator) Draw(s *tl.Screen) {
+	wind := w.game.Wind()
+
+	// more arrows are shown for stronger wind
+	left, right := "◀", "▶"
+	if w.asciiOnly {
+		left, right = "<", ">"
+	}
+	arrows := strings.Repeat(right, gmath.Abs(wind)/windPerArrow+1)
+	text := fmt.Sprintf(" Wind %d %s ", wind, arrows)
+	switch {
+	case wind < 0:
+		arrows = strings.Repeat(left, gmath.Abs(wind)/windPerArrow+1)
+		text = fmt.Sprintf(" Wind %s %d ", arrows, -wind)
+	case wind == 0:
+		text = " Wind 0 "
+	}
+
+	// draw text
+	sw, _ := s.Size()
+	colors := ui.ActivePallette.Standard
+	runes := []rune(text)
+	x := sw - len(runes) - 1
+	for i, ch := range runes {
+		s.RenderCell(x+i, 0, &tl.Cell{Fg: colors.Fg | tl.AttrBold, Bg: colors.Bg, Ch: ch})
+	}
+}
+
+// Tick does nothing now
+func (w *WindIndicator) Tick(e tl.Event) {}
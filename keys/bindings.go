@@ -0,0 +1,249 @@
+package keys
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tl "github.com/JoelOtter/termloop"
+)
+
+// Command is the name of the game action which can be bound to the keys
+type Command string
+
+const (
+	// AimLeft rotates cannon to the left
+	AimLeft Command = "aim-left"
+	// AimRight rotates cannon to the right
+	AimRight Command = "aim-right"
+	// Shoot starts loading or shoots if already loading
+	Shoot Command = "shoot"
+	// NextWeapon selects next weapon from the inventory
+	NextWeapon Command = "next-weapon"
+	// PreviousWeapon selects previous weapon from the inventory
+	PreviousWeapon Command = "previous-weapon"
+	// DriveLeft moves tank to the left
+	DriveLeft Command = "drive-left"
+	// DriveRight moves tank to the right
+	DriveRight Command = "drive-right"
+	// Aim shows form for typing exact angle and power of the next shot
+	Aim Command = "aim"
+	// RestartRound restarts current round
+	RestartRound Command = "restart-round"
+	// NextRound starts next round
+	NextRound Command = "next-round"
+	// Save saves the game
+	Save Command = "save"
+	// Score shows score board
+	Score Command = "score"
+	// Attributes shows attributes of players
+	Attributes Command = "attributes"
+	// Menu shows game menu
+	Menu Command = "menu"
+	// Settings shows form for changing key bindings
+	Settings Command = "settings"
+	// Help shows help with all key bindings
+	Help Command = "help"
+)
+
+// CommandInfo describes one command
+type CommandInfo struct {
+	// Command is the name of the command
+	Command Command
+	// Description is short text explaining what command does
+	Description string
+	// Player is true if the command controls tank of the player on turn and it can be bound separately for each player
+	Player bool
+}
+
+// Commands holds all commands in the order in which they should be shown to the user
+var Commands = []CommandInfo{
+	{Command: AimLeft, Description: "rotate cannon to the left", Player: true},
+	{Command: AimRight, Description: "rotate cannon to the right", Player: true},
+	{Command: Shoot, Description: "start loading (1st) and shoot (2nd)", Player: true},
+	{Command: NextWeapon, Description: "select next weapon", Player: true},
+	{Command: PreviousWeapon, Description: "select previous weapon", Player: true},
+	{Command: DriveLeft, Description: "drive to the left", Player: true},
+	{Command: DriveRight, Description: "drive to the right", Player: true},
+	{Command: Aim, Description: "type exact angle and power and shoot", Player: true},
+	{Command: RestartRound, Description: "restart current round"},
+	{Command: NextRound, Description: "start next round"},
+	{Command: Save, Description: "save game"},
+	{Command: Score, Description: "show score"},
+	{Command: Attributes, Description: "show player's attributes"},
+	{Command: Menu, Description: "show menu"},
+	{Command: Settings, Description: "change key bindings"},
+	{Command: Help, Description: "show help"},
+}
+
+// Info returns description of given command, ok is false if there is no such command
+func Info(c Command) (info CommandInfo, ok bool) {
+	for _, info := range Commands {
+		if info.Command == c {
+			return info, true
+		}
+	}
+	return CommandInfo{}, false
+}
+
+// Map holds keys bound to the commands.
+// One key can be bound only to one command but one command can have more keys.
+type Map map[Command][]Key
+
+// Default returns keys bound to the commands when nothing else is configured.
+// In browser mode there are additional keys for the round controls as Ctrl+R and Ctrl+N are used by the browser.
+func Default(browserMode bool) Map {
+	m := Map{
+		AimLeft:        {{Key: tl.KeyArrowLeft}},
+		AimRight:       {{Key: tl.KeyArrowRight}},
+		Shoot:          {{Key: tl.KeySpace}},
+		NextWeapon:     {{Ch: 'w'}},
+		PreviousWeapon: {{Ch: 'q'}},
+		DriveLeft:      {{Ch: 'z'}},
+		DriveRight:     {{Ch: 'x'}},
+		Aim:            {{Ch: 'f'}},
+		RestartRound:   {{Key: tl.KeyCtrlR}},
+		NextRound:      {{Key: tl.KeyCtrlN}},
+		Save:           {{Key: tl.KeyCtrlS}},
+		Score:          {{Ch: 's'}},
+		Attributes:     {{Ch: 'a'}},
+		Menu:           {{Ch: 'm'}},
+		Settings:       {{Ch: 'k'}},
+		Help:           {{Ch: 'h'}},
+	}
+	if browserMode {
+		m.Add(RestartRound, Key{Ch: 'r'})
+		m.Add(NextRound, Key{Ch: 'n'})
+	}
+	return m
+}
+
+// Command returns command bound to given key, ok is false if key is not bound
+func (m Map) Command(k Key) (c Command, ok bool) {
+	for c, keys := range m {
+		for _, key := range keys {
+			if key == k {
+				return c, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Bind binds given key to given command instead of all keys bound to it before
+func (m Map) Bind(c Command, k Key) {
+	delete(m, c)
+	m.Add(c, k)
+}
+
+// Add binds given key to given command together with keys bound to it before.
+// Key is unbound from any other command.
+func (m Map) Add(c Command, k Key) {
+	if other, ok := m.Command(k); ok {
+		m.remove(other, k)
+	}
+	m[c] = append(m[c], k)
+}
+
+// remove unbinds given key from given command
+func (m Map) remove(c Command, k Key) {
+	keys := []Key{}
+	for _, key := range m[c] {
+		if key != k {
+			keys = append(keys, key)
+		}
+	}
+	m[c] = keys
+}
+
+// Bindings holds keys bound to the commands for all players and for each player separately.
+//
+// When some player is on turn his own bindings are checked first.
+// Keys for all players are used only for commands which are not bound by the player on turn.
+type Bindings struct {
+	// Global holds keys used by all players
+	Global Map
+	// Players holds own keys of the players by their indexes
+	Players map[int]Map
+}
+
+// NewBindings creates bindings with default keys for all players and without own keys of the players
+func NewBindings(browserMode bool) Bindings {
+	return Bindings{Global: Default(browserMode), Players: map[int]Map{}}
+}
+
+// Command returns command bound to given key for player with given index.
+// Use negative index when no player is on turn.
+func (b *Bindings) Command(player int, k Key) (c Command, ok bool) {
+	own := b.Players[player]
+	if c, ok := own.Command(k); ok {
+		return c, true
+	}
+	if c, ok := b.Global.Command(k); ok && len(own[c]) == 0 {
+		return c, true
+	}
+	return "", false
+}
+
+// Keys returns all keys which run given command for player with given index.
+// Use negative index to get keys used by all players.
+func (b *Bindings) Keys(player int, c Command) []Key {
+	own := b.Players[player]
+	if len(own[c]) > 0 {
+		return own[c]
+	}
+	keys := []Key{}
+	for _, k := range b.Global[c] {
+		if _, ok := own.Command(k); !ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Player returns own keys of the player with given index, they are created if player has no own keys yet
+func (b *Bindings) Player(player int) Map {
+	if b.Players == nil {
+		b.Players = map[int]Map{}
+	}
+	if b.Players[player] == nil {
+		b.Players[player] = Map{}
+	}
+	return b.Players[player]
+}
+
+// Reset removes own keys of the player with given index
+func (b *Bindings) Reset(player int) {
+	delete(b.Players, player)
+}
+
+// ParseBinding parses binding in format [SLOT:]COMMAND=KEY, e.g. 2:shoot=Enter.
+// Returned player index is negative if there is no slot.
+func ParseBinding(value string) (player int, c Command, k Key, err error) {
+	player = -1
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return player, c, k, fmt.Errorf("Invalid key binding '%s', it should be in format [SLOT:]COMMAND=KEY", value)
+	}
+	name := strings.TrimSpace(parts[0])
+	if i := strings.Index(name, ":"); i >= 0 {
+		slot, err := strconv.Atoi(name[:i])
+		if err != nil || slot < 1 {
+			return player, c, k, fmt.Errorf("Invalid player slot '%s' in key binding '%s'", name[:i], value)
+		}
+		player = slot - 1
+		name = name[i+1:]
+	}
+	info, ok := Info(Command(name))
+	if !ok {
+		return player, c, k, fmt.Errorf("Unknown command '%s' in key binding '%s'", name, value)
+	}
+	if player >= 0 && !info.Player {
+		return player, c, k, fmt.Errorf("Command '%s' can not be bound for single player in key binding '%s'", name, value)
+	}
+	k, err = Parse(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return player, c, k, fmt.Errorf("Invalid key binding '%s': %w", value, err)
+	}
+	return player, info.Command, k, nil
+}
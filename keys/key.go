@@ -0,0 +1,114 @@
+// Package keys provides mapping of the pressed keys to the named commands of the game.
+package keys
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tl "github.com/JoelOtter/termloop"
+)
+
+// Key is one key or character which can be pressed.
+// Only one of Key and Ch is set, Ch is used for all printable characters.
+type Key struct {
+	// Key is special key like arrow or Ctrl+R, it's used only if Ch is 0
+	Key tl.Key
+	// Ch is printable character like 'w'
+	Ch rune
+}
+
+// keyNames holds names of special keys, names not found here are derived from Ctrl combinations
+var keyNames = map[tl.Key]string{
+	tl.KeyArrowLeft:  "Left",
+	tl.KeyArrowRight: "Right",
+	tl.KeyArrowUp:    "Up",
+	tl.KeyArrowDown:  "Down",
+	tl.KeySpace:      "Space",
+	tl.KeyEnter:      "Enter",
+	tl.KeyTab:        "Tab",
+	tl.KeyBackspace2: "Backspace",
+	tl.KeyInsert:     "Insert",
+	tl.KeyDelete:     "Delete",
+	tl.KeyHome:       "Home",
+	tl.KeyEnd:        "End",
+	tl.KeyPgup:       "PgUp",
+	tl.KeyPgdn:       "PgDn",
+	tl.KeyF1:         "F1",
+	tl.KeyF2:         "F2",
+	tl.KeyF3:         "F3",
+	tl.KeyF4:         "F4",
+	tl.KeyF5:         "F5",
+	tl.KeyF6:         "F6",
+	tl.KeyF7:         "F7",
+	tl.KeyF8:         "F8",
+	tl.KeyF9:         "F9",
+	tl.KeyF10:        "F10",
+	tl.KeyF11:        "F11",
+	tl.KeyF12:        "F12",
+}
+
+// ctrlPrefix is the prefix of the names of keys pressed together with Ctrl
+const ctrlPrefix = "Ctrl+"
+
+// FromEvent returns key pressed in given input event
+func FromEvent(e tl.Event) Key {
+	if e.Ch != 0 {
+		return Key{Ch: e.Ch}
+	}
+	return Key{Key: e.Key}
+}
+
+// Parse returns key with given name.
+// Name can be single character (e.g. w), name of the special key (e.g. Left, Space, F1) or Ctrl combination (e.g. Ctrl+R).
+// Names of special keys are case insensitive, characters are case sensitive.
+func Parse(name string) (Key, error) {
+	if utf8.RuneCountInString(name) == 1 {
+		ch, _ := utf8.DecodeRuneInString(name)
+		if ch == ' ' {
+			return Key{Key: tl.KeySpace}, nil
+		}
+		return Key{Ch: ch}, nil
+	}
+	for key, keyName := range keyNames {
+		if strings.EqualFold(name, keyName) {
+			return Key{Key: key}, nil
+		}
+	}
+	if len(name) == len(ctrlPrefix)+1 && strings.EqualFold(name[:len(ctrlPrefix)], ctrlPrefix) {
+		letter := strings.ToUpper(name[len(ctrlPrefix):])[0]
+		if letter >= 'A' && letter <= 'Z' {
+			return Key{Key: tl.KeyCtrlA + tl.Key(letter-'A')}, nil
+		}
+	}
+	return Key{}, fmt.Errorf("Unknown key '%s'", name)
+}
+
+// String returns name of the key which can be parsed back by Parse
+func (k Key) String() string {
+	if k.Ch != 0 {
+		return string(k.Ch)
+	}
+	if name, ok := keyNames[k.Key]; ok {
+		return name
+	}
+	if k.Key >= tl.KeyCtrlA && k.Key <= tl.KeyCtrlZ {
+		return ctrlPrefix + string(rune('A'+k.Key-tl.KeyCtrlA))
+	}
+	return fmt.Sprintf("Key(%d)", k.Key)
+}
+
+// MarshalText encodes key as it's name
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes key from it's name
+func (k *Key) UnmarshalText(text []byte) error {
+	key, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*k = key
+	return nil
+}
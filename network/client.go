@@ -0,0 +1,173 @@
+package network
+
+import (
+	"errors"
+	"net"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched"
+	"github.com/zladovan/gorched/debug"
+	"github.com/zladovan/gorched/hud/ui"
+)
+
+// Client is entity which keeps local game synchronized with the game running on the host.
+// Use Join to connect to the host and then Attach to start synchronization of the local game.
+// Client needs to be added to the game screen.
+type Client struct {
+	// game is the local game following host's game
+	game *gorched.Game
+	// host is connection to the host
+	host *peer
+	// welcome is the response of the host received after joining
+	welcome *Welcome
+	// messages receives all messages from the host
+	messages chan Message
+	// queue holds messages which were received but they are not applied yet
+	queue []Message
+	// disconnected is closed when connection to host is lost
+	disconnected chan struct{}
+}
+
+// Join connects to the host on given address and waits until host assigns player to this client.
+// If spectator is true client will only watch the game and no player is assigned to it.
+// Returned client is not yet synchronizing any game, call Attach to do it.
+func Join(address string, spectator bool) (*Client, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	p := newPeer(conn)
+	if err := p.encoder.Encode(Message{Hello: &Hello{Version: ProtocolVersion, Spectator: spectator}}); err != nil {
+		p.close()
+		return nil, err
+	}
+	m, err := p.receive()
+	if err != nil {
+		p.close()
+		return nil, err
+	}
+	if m.Welcome == nil {
+		p.close()
+		return nil, errors.New("Unexpected response from host")
+	}
+	if m.Welcome.Error != "" {
+		p.close()
+		return nil, errors.New(m.Welcome.Error)
+	}
+	return &Client{
+		host:         p,
+		welcome:      m.Welcome,
+		messages:     make(chan Message, 256),
+		disconnected: make(chan struct{}),
+	}, nil
+}
+
+// Options returns game options needed to create local game compatible with host's game.
+// Only world related options are changed in given options.
+// Options bound to the player slots are taken from the host, e.g. names of players are part of the host's snapshot.
+func (c *Client) Options(o gorched.GameOptions) gorched.GameOptions {
+	o.Width = c.welcome.Width
+	o.Height = c.welcome.Height
+	o.Seed = c.welcome.Seed
+	o.PlayerCount = c.welcome.PlayerCount
+	o.Wind = c.welcome.Wind
+	o.Colors = c.welcome.Colors
+	o.World = c.welcome.World
+	o.Bots = nil
+	o.Names = nil
+	o.Remotes = []int{}
+	for i := 0; i < o.PlayerCount; i++ {
+		if i != c.welcome.Slot {
+			o.Remotes = append(o.Remotes, i)
+		}
+	}
+	o.Client = true
+	o.Spectator = c.IsSpectator()
+	return o
+}
+
+// IsSpectator returns true if this client is only watching the game
+func (c *Client) IsSpectator() bool {
+	return c.welcome.Slot < 0
+}
+
+// Attach starts synchronization of given game with the host's game.
+// All actions of the local player will be sent to the host instead of applying them directly.
+func (c *Client) Attach(game *gorched.Game) {
+	c.game = game
+	game.Controls().Handler = c.handle
+	go c.host.write()
+	go c.read()
+}
+
+// Close disconnects from the host
+func (c *Client) Close() {
+	c.host.close()
+}
+
+// read reads messages from the host until connection is closed
+func (c *Client) read() {
+	defer close(c.disconnected)
+	for {
+		m, err := c.host.receive()
+		if err != nil {
+			c.host.close()
+			return
+		}
+		c.messages <- m
+	}
+}
+
+// handle sends action of the local player to the host.
+// Spectators are not sending anything.
+func (c *Client) handle(a gorched.Action, aim gorched.Aim) {
+	if c.IsSpectator() {
+		return
+	}
+	c.host.send(Message{Input: &Input{Player: c.welcome.Slot, Action: a, Angle: aim.Angle, Power: aim.Power}})
+}
+
+// Draw applies all messages received from the host
+func (c *Client) Draw(s *tl.Screen) {
+	for {
+		select {
+		case m := <-c.messages:
+			c.queue = append(c.queue, m)
+			continue
+		case <-c.disconnected:
+			c.disconnected = nil
+			debug.Log("Connection to host lost")
+			c.game.Hud().ShowForm(ui.NewMessageBox("Connection to host lost. Press Ctrl+C to exit."))
+		default:
+		}
+		break
+	}
+	for len(c.queue) > 0 && c.apply(c.queue[0]) {
+		c.queue = c.queue[1:]
+	}
+}
+
+// Tick does nothing now
+func (c *Client) Tick(e tl.Event) {}
+
+// apply applies message from the host.
+// It returns false if message cannot be applied yet because local game is not in the same state as host's game.
+func (c *Client) apply(m Message) bool {
+	round := c.game.Round()
+	switch {
+	case m.Snapshot != nil:
+		// wait until local turn is finished to do not break it
+		if round.IsTurnInProgress() {
+			return false
+		}
+		c.game.Restore(m.Snapshot)
+	case m.Input != nil:
+		// wait until the same player is on turn locally
+		if !round.IsPlayerOnTurn() || round.ActivePlayerIndex() != m.Input.Player {
+			return false
+		}
+		c.game.Controls().Apply(m.Input.Action, gorched.Aim{Angle: m.Input.Angle, Power: m.Input.Power})
+		round.ActiveTank().SetAim(m.Input.Angle, m.Input.Power)
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+package network
+
+import (
+	"net"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched"
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/debug"
+)
+
+// Host is entity which accepts clients and keeps their games synchronized with the game running in this process.
+// Clients can join as players or as spectators.
+// Use Listen to create new Host.
+// Host needs to be added to the game screen.
+type Host struct {
+	// game is the authoritative game
+	game *gorched.Game
+	// listener accepts new connections
+	listener net.Listener
+	// clients holds connected clients by the index of player assigned to them
+	clients map[int]*peer
+	// spectators holds connected clients which are only watching the game
+	spectators map[*peer]bool
+	// joins receives newly connected clients
+	joins chan remoteJoin
+	// leaves receives disconnected clients
+	leaves chan *peer
+	// inputs receives inputs from clients
+	inputs chan remoteInput
+	// outdated is true when new turn was started and clients need to receive actual snapshot
+	outdated bool
+}
+
+// remoteJoin is request of newly connected client to join the game
+type remoteJoin struct {
+	from      *peer
+	spectator bool
+}
+
+// remoteInput is input received from some client
+type remoteInput struct {
+	from  *peer
+	input Input
+}
+
+// Listen starts listening for clients on given address.
+// Inputs of all players are then broadcasted to the connected clients.
+func Listen(game *gorched.Game, address string) (*Host, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	h := &Host{
+		game:       game,
+		listener:   listener,
+		clients:    map[int]*peer{},
+		spectators: map[*peer]bool{},
+		joins:      make(chan remoteJoin),
+		leaves:     make(chan *peer),
+		inputs:     make(chan remoteInput),
+	}
+	game.Controls().Handler = h.handle
+	game.Events().Subscribe(h.observe)
+	go h.accept()
+	return h, nil
+}
+
+// accept accepts new connections until listener is closed
+func (h *Host) accept() {
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			return
+		}
+		go h.serve(newPeer(conn))
+	}
+}
+
+// serve reads messages from given client until the connection is closed
+func (h *Host) serve(p *peer) {
+	defer func() {
+		p.close()
+		h.leaves <- p
+	}()
+
+	m, err := p.receive()
+	if err != nil || m.Hello == nil {
+		return
+	}
+	if m.Hello.Version != ProtocolVersion {
+		p.encoder.Encode(Message{Welcome: &Welcome{Error: "Incompatible protocol version"}})
+		return
+	}
+
+	go p.write()
+	h.joins <- remoteJoin{from: p, spectator: m.Hello.Spectator}
+
+	for {
+		m, err := p.receive()
+		if err != nil {
+			return
+		}
+		if m.Input != nil {
+			h.inputs <- remoteInput{from: p, input: *m.Input}
+		}
+	}
+}
+
+// Close stops accepting new clients and disconnects all connected clients
+func (h *Host) Close() {
+	h.listener.Close()
+	for _, p := range h.clients {
+		p.close()
+	}
+	for p := range h.spectators {
+		p.close()
+	}
+}
+
+// Draw processes all events from clients and sends snapshot when needed
+func (h *Host) Draw(s *tl.Screen) {
+	for {
+		select {
+		case j := <-h.joins:
+			h.join(j)
+		case p := <-h.leaves:
+			h.leave(p)
+		case in := <-h.inputs:
+			h.receive(in)
+		default:
+			h.sync()
+			return
+		}
+	}
+}
+
+// Tick does nothing now
+func (h *Host) Tick(e tl.Event) {}
+
+// join assigns first free remote player to the client and sends him initial state.
+// Spectators are not assigned to any player.
+func (h *Host) join(j remoteJoin) {
+	p := j.from
+	slot := -1
+	if j.spectator {
+		h.spectators[p] = true
+		debug.Log("Spectator joined")
+	} else {
+		slot = h.freeSlot()
+		if slot < 0 {
+			// connection is closed right after the error is sent
+			p.send(Message{Welcome: &Welcome{Error: "There is no free player slot"}})
+			return
+		}
+		h.clients[slot] = p
+		debug.Logf("Client joined as player %d", slot+1)
+	}
+	w, ht := h.game.Round().World().Size()
+	p.send(Message{Welcome: &Welcome{
+		Slot:        slot,
+		Width:       w,
+		Height:      ht,
+		Seed:        h.game.InitialSeed(),
+		PlayerCount: len(h.game.Players()),
+		Wind:        h.game.Options().Wind,
+		Colors:      h.game.Options().Colors,
+		World:       h.game.Options().World,
+	}})
+	p.send(Message{Snapshot: h.game.Snapshot()})
+}
+
+// leave releases player slot of disconnected client.
+// Player of the slot is then controlled by computer, otherwise the game would stall on his turn.
+func (h *Host) leave(p *peer) {
+	if h.spectators[p] {
+		delete(h.spectators, p)
+		debug.Log("Spectator left")
+		return
+	}
+	for slot, c := range h.clients {
+		if c == p {
+			delete(h.clients, slot)
+			h.game.HandToComputer(slot)
+			debug.Logf("Client of player %d left, player is controlled by computer now", slot+1)
+		}
+	}
+}
+
+// freeSlot returns index of the first remote player without client or -1 if there is no such player
+func (h *Host) freeSlot() int {
+	for i, player := range h.game.Players() {
+		if player.Type == core.Remote && h.clients[i] == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// receive applies input from client if it's from player which is on turn
+func (h *Host) receive(in remoteInput) {
+	round := h.game.Round()
+	if h.clients[round.ActivePlayerIndex()] != in.from {
+		return
+	}
+	h.handle(in.input.Action, gorched.Aim{Angle: in.input.Angle, Power: in.input.Power})
+}
+
+// handle applies action to the active tank and broadcasts it to all clients
+func (h *Host) handle(a gorched.Action, aim gorched.Aim) {
+	round := h.game.Round()
+	if !round.IsPlayerOnTurn() {
+		return
+	}
+	h.game.Controls().Apply(a, aim)
+	tank := round.ActiveTank()
+	h.broadcast(Message{Input: &Input{
+		Player: round.ActivePlayerIndex(),
+		Action: a,
+		Angle:  tank.Angle(),
+		Power:  tank.Power(),
+	}})
+}
+
+// observe marks clients outdated when new turn is started, it's also the first turn of each round
+func (h *Host) observe(e core.Event) {
+	if _, ok := e.(core.TurnStarted); ok {
+		h.outdated = true
+	}
+}
+
+// sync broadcasts snapshot when clients are outdated
+func (h *Host) sync() {
+	if h.outdated {
+		h.broadcast(Message{Snapshot: h.game.Snapshot()})
+		h.outdated = false
+	}
+}
+
+// broadcast sends message to all clients including spectators
+func (h *Host) broadcast(m Message) {
+	for _, p := range h.clients {
+		p.send(m)
+	}
+	for p := range h.spectators {
+		p.send(m)
+	}
+}
@@ -0,0 +1,152 @@
+// Package network contains support for playing one game from multiple processes over TCP.
+//
+// One process is the host. It owns the authoritative game and it accepts connections from clients.
+// Each client is assigned to one of the remote player slots.
+//
+// Clients are sending inputs of their players to the host.
+// Host applies only inputs of the player which is on turn and broadcasts them to all clients.
+// Host also broadcasts snapshot of the whole game state at the start of each turn.
+// Clients are applying inputs and snapshots in the same order as they were sent by host.
+//
+// Client can also join as a spectator. Spectator is not assigned to any player slot,
+// it only receives inputs and snapshots and it cannot send any input.
+// There is no limit for the number of spectators and they can join at any time.
+//
+// Messages are encoded as JSON, one message per line.
+package network
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+
+	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched"
+	"github.com/zladovan/gorched/entities"
+)
+
+// ProtocolVersion is version of the protocol, host and client need to use the same version
+const ProtocolVersion = 3
+
+// Message is single message sent between host and client.
+// Only one of the fields is set in each message.
+type Message struct {
+	Hello    *Hello            `json:",omitempty"`
+	Welcome  *Welcome          `json:",omitempty"`
+	Input    *Input            `json:",omitempty"`
+	Snapshot *gorched.Snapshot `json:",omitempty"`
+}
+
+// Hello is the first message sent by client after it's connected
+type Hello struct {
+	// Version is the protocol version used by client
+	Version int
+	// Spectator is true when client wants only to watch the game
+	Spectator bool `json:",omitempty"`
+}
+
+// Welcome is sent by host as the response for Hello
+type Welcome struct {
+	// Error is set when client cannot join the game
+	Error string `json:",omitempty"`
+	// Slot is index of the player assigned to the client, it's -1 for spectators
+	Slot int
+	// Width of game world
+	Width int
+	// Height of game world
+	Height int
+	// Seed used by host for the first round
+	Seed int64
+	// PlayerCount is number of players in the game
+	PlayerCount int
+	// Wind defines how the wind behaves in host's game
+	Wind entities.WindMode
+	// Colors holds custom colors of tanks in host's game
+	Colors map[int]tl.Attr `json:",omitempty"`
+	// World holds values affecting physics and generation of host's game world
+	World entities.Tunables
+}
+
+// Input is one action of the player on turn
+type Input struct {
+	// Player is index of the player who did the action
+	Player int
+	// Action is the action done by the player
+	Action gorched.Action
+	// Angle is angle of player's tank after action was applied on host.
+	// When it's sent by client it's the angle used by Fire action.
+	Angle int
+	// Power is shooting power of player's tank after action was applied on host.
+	// When it's sent by client it's the power used by Fire action.
+	Power int
+}
+
+// peer is one side of the connection which can send and receive messages
+type peer struct {
+	conn    net.Conn
+	encoder *json.Encoder
+	decoder *json.Decoder
+	// out holds messages waiting to be sent
+	out chan Message
+	// done is closed when connection is closed
+	done chan struct{}
+	// closeOnce ensures that connection is closed only once
+	closeOnce sync.Once
+}
+
+// newPeer creates peer for given connection
+func newPeer(conn net.Conn) *peer {
+	return &peer{
+		conn:    conn,
+		encoder: json.NewEncoder(conn),
+		decoder: json.NewDecoder(conn),
+		out:     make(chan Message, 256),
+		done:    make(chan struct{}),
+	}
+}
+
+// send queues message to be sent by writer.
+// If there are too many messages waiting, connection is considered as broken and it is closed.
+func (p *peer) send(m Message) {
+	select {
+	case p.out <- m:
+	default:
+		p.close()
+	}
+}
+
+// receive reads next message, it blocks until some message is received
+func (p *peer) receive() (Message, error) {
+	m := Message{}
+	err := p.decoder.Decode(&m)
+	return m, err
+}
+
+// write sends all queued messages until connection is closed.
+// Connection is closed also right after welcome with error is sent.
+func (p *peer) write() {
+	for {
+		select {
+		case m := <-p.out:
+			if err := p.encoder.Encode(m); err != nil {
+				p.close()
+				return
+			}
+			// welcome with error is the last message, client was not accepted
+			if m.Welcome != nil && m.Welcome.Error != "" {
+				p.close()
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// close closes underlying connection
+func (p *peer) close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+		p.conn.Close()
+	})
+}
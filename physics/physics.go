@@ -8,9 +8,13 @@ import "github.com/zladovan/gorched/gmath"
 // There are no other collisions resolved here instead of landing on the ground.
 // If you want to apply physics to your object implement HasBody interface.
 // If you want to let your object land on the ground implement Lander interface too.
+// If you want to let your object drift in the wind implement Drifter interface too.
+// If you want to know when your object landed after the fall implement LandingHandler interface too.
 type Physics struct {
 	// Gravity holds gravitational acceleration
 	Gravity float64
+	// Wind holds horizontal acceleration caused by the wind, positive values are blowing to the right
+	Wind float64
 	// Ground resolves nearest ground y coordinate for position given by x and y
 	Ground func(x, y int) int
 }
@@ -25,6 +29,8 @@ type Body struct {
 	Mass float64
 	// Locked if is true no physics is applied to this body
 	Locked bool
+	// FallStart holds y coordinate where the body was not moving vertically for the last time, it's the place where the fall started
+	FallStart float64
 }
 
 // HasBody describes some object which can provide physical Body.
@@ -38,10 +44,25 @@ type Lander interface {
 	BottomLine() (int, int)
 }
 
+// Drifter describes some object which is drifting in the wind.
+// It should return how much is the object affected by the wind, 1 means that whole wind acceleration is applied.
+type Drifter interface {
+	Windage() float64
+}
+
+// LandingHandler describes some object which wants to know when it landed on the ground.
+// Landed is called with the height of the fall and with the vertical speed right before the landing.
+// It's called also when object is just lying on the ground, height is zero then.
+type LandingHandler interface {
+	Landed(height, speed float64)
+}
+
 // Apply will update body according to this physical model.
 // Velocity will be updated by gravitational accelleration.
+// Velocity will be updated also by wind accelleration if given object e implements Drifter interface.
 // Position will be updated by velocity.
 // Position could be trimmed to the ground positions if given object e implements Lander interface too.
+// Object e is notified about landing if it implements LandingHandler interface too.
 func (p *Physics) Apply(e HasBody, dt float64) {
 	body := e.Body()
 	y := int(body.Position.Y)
@@ -51,9 +72,19 @@ func (p *Physics) Apply(e HasBody, dt float64) {
 		return
 	}
 
+	// fall starts where body is not moving vertically
+	if body.Velocity.Y == 0 {
+		body.FallStart = body.Position.Y
+	}
+
 	// update velocity by gravity
 	body.Velocity.Y += p.Gravity * dt * body.Mass
 
+	// update velocity by wind
+	if drifter, ok := e.(Drifter); ok {
+		body.Velocity.X += p.Wind * drifter.Windage() * dt
+	}
+
 	// update position by velocity
 	body.Position.X += body.Velocity.X * dt
 	body.Position.Y += body.Velocity.Y * dt
@@ -68,8 +99,12 @@ func (p *Physics) Apply(e HasBody, dt float64) {
 		for i := minx; i <= maxx; i++ {
 			groundy := p.Ground(i, y)
 			if int(body.Position.Y) > groundy {
+				speed := body.Velocity.Y
 				body.Position.Y = float64(groundy)
 				body.Velocity.Y = 0
+				if handler, ok := e.(LandingHandler); ok {
+					handler.Landed(body.Position.Y-body.FallStart, speed)
+				}
 				break
 			}
 		}
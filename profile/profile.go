@@ -0,0 +1,92 @@
+// Package profile provides named player profiles with career statistics kept between games.
+package profile
+
+import (
+	"math"
+	"sort"
+
+	"github.com/zladovan/gorched/core"
+)
+
+// InitialRating is rating of the new profile and of all players playing without profile
+const InitialRating = 1000
+
+// ratingFactor is maximal change of the rating after one round against one opponent
+const ratingFactor = 32
+
+// Profile holds career statistics of one named player collected during all played games
+type Profile struct {
+	// Name is the unique name of the profile, it's also used as the name of the player
+	Name string
+	// Games is number of games played with this profile
+	Games int
+	// Rounds is number of finished rounds played with this profile
+	Rounds int
+	// Wins is number of rounds where player was the last one alive
+	Wins int
+	// Losses is number of rounds where player was not the last one alive
+	Losses int
+	// Stats are lifetime statistics about kills, deaths, suicides and damage
+	Stats core.Stats
+	// Shots is number of fired shots
+	Shots int
+	// Hits is number of shots which damaged some enemy
+	Hits int
+	// Weapons holds number of shots by the name of the weapon used
+	Weapons map[string]int
+	// Rating is Elo rating of the player changed after each round with some winner
+	Rating float64
+}
+
+// New creates new profile with given name
+func New(name string) *Profile {
+	return &Profile{Name: name, Weapons: map[string]int{}, Rating: InitialRating}
+}
+
+// Accuracy returns percentage of shots which damaged some enemy
+func (p *Profile) Accuracy() float64 {
+	if p.Shots == 0 {
+		return 0
+	}
+	return float64(p.Hits) / float64(p.Shots) * 100
+}
+
+// FavouriteWeapon returns name of the most used weapon.
+// When more weapons were used the same number of times the first one in alphabetical order is returned.
+// It returns empty string if there was no shot yet.
+func (p *Profile) FavouriteWeapon() string {
+	names := []string{}
+	for name := range p.Weapons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	favourite := ""
+	for _, name := range names {
+		if favourite == "" || p.Weapons[name] > p.Weapons[favourite] {
+			favourite = name
+		}
+	}
+	return favourite
+}
+
+// add adds all values collected in given round to this profile, rating is not changed
+func (p *Profile) add(r *Profile) {
+	p.Rounds += r.Rounds
+	p.Wins += r.Wins
+	p.Losses += r.Losses
+	p.Stats.Kills += r.Stats.Kills
+	p.Stats.Deaths += r.Stats.Deaths
+	p.Stats.Suicides += r.Stats.Suicides
+	p.Stats.Damage += r.Stats.Damage
+	p.Shots += r.Shots
+	p.Hits += r.Hits
+	for name, shots := range r.Weapons {
+		p.Weapons[name] += shots
+	}
+}
+
+// ratingChange returns how much the rating of the winner increases and the rating of the loser decreases after one round
+func ratingChange(winner, loser float64) float64 {
+	expected := 1 / (1 + math.Pow(10, (loser-winner)/400))
+	return ratingFactor * (1 - expected)
+}
@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// fileName is the name of the file where profiles are stored
+const fileName = "profiles.json"
+
+// Store holds all profiles loaded from the file.
+// Use Load to create new Store and Save to write all changes back.
+type Store struct {
+	// path is the path of the file with profiles
+	path string
+	// profiles holds all profiles by their names
+	profiles map[string]*Profile
+}
+
+// DefaultPath returns path of the file with profiles in the XDG data directory.
+// It's $XDG_DATA_HOME/gorched/profiles.json or ~/.local/share/gorched/profiles.json when XDG_DATA_HOME is not set.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "gorched", fileName), nil
+}
+
+// Load reads profiles from the file on given path.
+// Store will be empty if the file does not exist yet.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, profiles: map[string]*Profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	profiles := []*Profile{}
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		if p.Weapons == nil {
+			p.Weapons = map[string]int{}
+		}
+		s.profiles[p.Name] = p
+	}
+	return s, nil
+}
+
+// Get returns profile with given name, new profile is created if there is no such profile yet
+func (s *Store) Get(name string) *Profile {
+	p := s.profiles[name]
+	if p == nil {
+		p = New(name)
+		s.profiles[name] = p
+	}
+	return p
+}
+
+// Find returns profile with given name or nil if there is no such profile
+func (s *Store) Find(name string) *Profile {
+	return s.profiles[name]
+}
+
+// Profiles returns all profiles sorted by rating from the best one
+func (s *Store) Profiles() []*Profile {
+	profiles := make([]*Profile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].Rating != profiles[j].Rating {
+			return profiles[i].Rating > profiles[j].Rating
+		}
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// Save writes all profiles to the file, directory is created if needed.
+// File is replaced at once so it's never left half written.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s.Profiles(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Path returns path of the file with profiles
+func (s *Store) Path() string {
+	return s.path
+}
@@ -0,0 +1,125 @@
+package profile
+
+import "github.com/zladovan/gorched/core"
+
+// Tracker collects career statistics of players with profiles from the game events.
+// Subscribe Handle to the game events to start tracking.
+// Only finished rounds are added to the profiles, rounds which were restarted or not finished are ignored.
+type Tracker struct {
+	// profiles holds profiles of tracked players
+	profiles map[*core.Player]*Profile
+	// round holds values collected during the actual round for each tracked player
+	round map[*core.Player]*Profile
+	// shooter is the player who fired the last shot in the actual turn, it's nil if nobody fired yet
+	shooter *core.Player
+	// hit is true if the last shot damaged some enemy of the shooter
+	hit bool
+}
+
+// NewTracker creates tracker which will update given profiles of the players
+func NewTracker(profiles map[*core.Player]*Profile) *Tracker {
+	return &Tracker{profiles: profiles, round: map[*core.Player]*Profile{}}
+}
+
+// Handle updates statistics according to given event
+func (t *Tracker) Handle(e core.Event) {
+	switch e := e.(type) {
+	case core.RoundStarted:
+		t.round = map[*core.Player]*Profile{}
+		t.shooter = nil
+	case core.TurnStarted:
+		t.finishShot()
+	case core.ShotFired:
+		t.finishShot()
+		t.shooter = e.Tank.Player
+		if r := t.roundProfile(t.shooter); r != nil {
+			r.Shots++
+			r.Weapons[e.Weapon]++
+		}
+	case core.TankDamaged:
+		if e.Enemy != nil && e.Enemy != e.Tank && e.Enemy.Player == t.shooter {
+			t.hit = true
+		}
+	case core.RoundFinished:
+		t.finishShot()
+		t.finishRound(e.Round)
+	case core.GameOver:
+		for _, p := range t.profiles {
+			p.Games++
+		}
+	}
+}
+
+// roundProfile returns values collected in the actual round for given player or nil if player is not tracked
+func (t *Tracker) roundProfile(player *core.Player) *Profile {
+	if t.profiles[player] == nil {
+		return nil
+	}
+	r := t.round[player]
+	if r == nil {
+		r = New(player.Name)
+		t.round[player] = r
+	}
+	return r
+}
+
+// finishShot counts the last shot as hit if it damaged some enemy
+func (t *Tracker) finishShot() {
+	if t.hit {
+		if r := t.roundProfile(t.shooter); r != nil {
+			r.Hits++
+		}
+	}
+	t.shooter = nil
+	t.hit = false
+}
+
+// finishRound adds values collected in given finished round to the profiles and updates ratings.
+// The only tank alive is the winner, all other tanks lost against it.
+func (t *Tracker) finishRound(round *core.Round) {
+	var winner *core.Tank
+	for _, tank := range round.Tanks {
+		if tank.IsAlive() {
+			winner = tank
+		}
+	}
+
+	// rating changes are calculated from the ratings before the round
+	changes := map[*core.Player]float64{}
+	if winner != nil {
+		for _, tank := range round.Tanks {
+			if tank == winner {
+				continue
+			}
+			change := ratingChange(t.rating(winner.Player), t.rating(tank.Player))
+			changes[winner.Player] += change
+			changes[tank.Player] -= change
+		}
+	}
+
+	for _, tank := range round.Tanks {
+		r := t.roundProfile(tank.Player)
+		if r == nil {
+			continue
+		}
+		r.Rounds++
+		r.Stats = tank.Stats
+		if tank == winner {
+			r.Wins++
+		} else {
+			r.Losses++
+		}
+		p := t.profiles[tank.Player]
+		p.add(r)
+		p.Rating += changes[tank.Player]
+	}
+	t.round = map[*core.Player]*Profile{}
+}
+
+// rating returns rating of given player, InitialRating is used for players without profile
+func (t *Tracker) rating(player *core.Player) float64 {
+	if p := t.profiles[player]; p != nil {
+		return p.Rating
+	}
+	return InitialRating
+}
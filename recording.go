@@ -0,0 +1,125 @@
+package gorched
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	tl "github.com/JoelOtter/termloop"
+)
+
+// Recording holds options of the recorded game and all input events received during the game.
+//
+// It is stored as JSON lines.
+// The first line contains game options and each next line contains one input event.
+// Game started with the same options will lead to the same results when recorded events are applied in the same simulation ticks.
+type Recording struct {
+	// Options holds options used to create recorded game
+	Options GameOptions
+	// Events holds all input events in the order in which they were received
+	Events []RecordedEvent `json:",omitempty"`
+}
+
+// RecordedEvent is one input event received during the recorded game
+type RecordedEvent struct {
+	// Tick is number of simulation steps done before event was received
+	Tick int64
+	// Seed is seed of the round in which event was received
+	Seed int64
+	// Type is type of the event
+	Type tl.EventType
+	// Key is the key pressed, if any
+	Key tl.Key `json:",omitempty"`
+	// Ch is the character of the key, if any
+	Ch rune `json:",omitempty"`
+	// Mod is a keyboard modifier, if any
+	Mod tl.Modifier `json:",omitempty"`
+	// MouseX is mouse x coordinate, if any
+	MouseX int `json:",omitempty"`
+	// MouseY is mouse y coordinate, if any
+	MouseY int `json:",omitempty"`
+	// Width is width of the screen after resize, if any
+	Width int `json:",omitempty"`
+	// Height is height of the screen after resize, if any
+	Height int `json:",omitempty"`
+}
+
+// newRecordedEvent creates recorded event from given termloop event
+func newRecordedEvent(e tl.Event) RecordedEvent {
+	return RecordedEvent{
+		Type:   e.Type,
+		Key:    e.Key,
+		Ch:     e.Ch,
+		Mod:    e.Mod,
+		MouseX: e.MouseX,
+		MouseY: e.MouseY,
+	}
+}
+
+// Event returns termloop event which was recorded
+func (r RecordedEvent) Event() tl.Event {
+	return tl.Event{
+		Type:   r.Type,
+		Key:    r.Key,
+		Ch:     r.Ch,
+		Mod:    r.Mod,
+		MouseX: r.MouseX,
+		MouseY: r.MouseY,
+	}
+}
+
+// Recorder writes input events of the game as they are received
+type Recorder struct {
+	// game refers to the main game object
+	game *Game
+	// encoder writes events as JSON lines
+	encoder *json.Encoder
+	// err holds first error which occurred while writing, nothing is written after it
+	err error
+}
+
+// NewRecorder creates recorder for given game writing to given writer.
+// Game options are written immediately.
+func NewRecorder(game *Game, w io.Writer) (*Recorder, error) {
+	r := &Recorder{game: game, encoder: json.NewEncoder(w)}
+	if err := r.encoder.Encode(Recording{Options: game.options}); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// record writes given event together with actual simulation tick and round seed
+func (r *Recorder) record(e RecordedEvent) {
+	if r.err != nil {
+		return
+	}
+	e.Tick = r.game.Ticks()
+	e.Seed = r.game.LastSeed()
+	r.err = r.encoder.Encode(e)
+}
+
+// Err returns first error which occurred while writing events
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+// LoadRecording reads recording written by Recorder
+func LoadRecording(r io.Reader) (*Recording, error) {
+	decoder := json.NewDecoder(r)
+	recording := &Recording{}
+	if err := decoder.Decode(recording); err != nil {
+		return nil, fmt.Errorf("Invalid recording header: %w", err)
+	}
+	for {
+		e := RecordedEvent{}
+		err := decoder.Decode(&e)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid recorded event %d: %w", len(recording.Events)+1, err)
+		}
+		recording.Events = append(recording.Events, e)
+	}
+	return recording, nil
+}
@@ -2,11 +2,13 @@ package gorched
 
 import (
 	tl "github.com/JoelOtter/termloop"
+	"github.com/zladovan/gorched/core"
 	"github.com/zladovan/gorched/entities"
 )
 
 // Round represents one round in the game.
-// It is responsible for managing state of the round.
+// Turn state machine and scoring are implemented by core.Round model.
+// Round connects the model with the world and the HUD.
 // It also provides functionality for transitions to next rounds.
 // Use NewRound to create new instance.
 // Add it to the termloop.Screen entities after creating.
@@ -17,89 +19,72 @@ type Round struct {
 	game *Game
 	// world refers to actual game world
 	world *entities.World
-	// index is the index of current round, starting from zero for first round
-	index int
-	// state holds the state of this round
-	state RoundState
+	// model holds the state of this round
+	model *core.Round
 	// tanks contains all tanks in game
 	tanks []*entities.Tank
-	// startingPlayerIndex holds index of the player which is first on turn in this round
-	startingPlayerIndex int
-	// onTurnPlayerIndex is index of the player currently on turn
-	onTurnPlayerIndex int
-}
-
-// RoundState represents state of the round
-type RoundState uint8
-
-const (
-	// Started round is state right after round was created / started / restarted
-	Started RoundState = iota
-	// PlayerOnTurn is state when some player is on turn but it has not done his move yet
-	PlayerOnTurn
-	// WaitForTurnFinish is state when some player did his move and we are waiting for all consequences of the move
-	WaitForTurnFinish
-	// Finished is state when round was finished which means there is only one or zero tanks alive
-	Finished
-)
+}
 
 // NewRound creates new round.
 // Created round will be in Started state.
-// It will add World as level after added to the screen.
+// It will add World as level in the first simulation step.
 func NewRound(game *Game) *Round {
 	round := &Round{game: game}
-	round.Restart()
+	round.start(0, 0)
+	game.Events().Subscribe(func(e core.Event) {
+		round.world.Handle(e)
+	})
 	return round
 }
 
-// Draw is processing Round states
-func (r *Round) Draw(s *tl.Screen) {
-	switch r.state {
-	case Started:
-		s.SetLevel(r.world)
-		r.onTurnPlayerIndex = r.startingPlayerIndex
-		r.state = PlayerOnTurn
-	case PlayerOnTurn:
-		if r.ActiveTank().IsShooting() {
-			r.state = WaitForTurnFinish
-		}
-	case WaitForTurnFinish:
-		if r.IsTurnFinished() {
-			if r.NumberOfTanksAlive() <= 1 {
-				r.finishRound()
-			} else {
-				r.state = PlayerOnTurn
-				r.ActivateNextTank()
-			}
-		}
+// Draw does nothing now
+func (r *Round) Draw(s *tl.Screen) {}
+
+// Update advances the world by one simulation step and is processing Round states.
+// World is set as level of the screen in the first step after round was started.
+func (r *Round) Update() {
+	if r.model.State == core.Started {
+		r.game.engine.Screen().SetLevel(r.world)
+	}
+	r.world.Update()
+
+	state := r.model.State
+	r.model.Update(r.IsTurnFinished())
+	switch {
+	case state != core.Finished && r.model.State == core.Finished:
+		r.finishRound()
+	case state == core.WaitForTurnFinish && r.model.State == core.PlayerOnTurn:
+		r.world.ChangeWind()
 	}
 }
 
 // finishRound is called when round is finished
 func (r *Round) finishRound() {
-	r.state = Finished
-
 	// states gained during this round are added to players on round finish
-	// all players that didn't made suicide gain one point
-	// winner gain one more point
-	for pi, player := range r.game.players {
-		tank := r.tanks[pi]
-		player.AddStats(tank.Stats())
-		if tank.Stats().Suicides == 0 {
-			player.Attributes.Points++
-		}
-		if tank.IsAlive() {
-			player.Attributes.Points++
-		}
+	r.model.Score(r.game.options.Interest)
+
+	// client is only showing score, next round will be started by host
+	if r.game.options.Client {
+		r.game.Hud().ShowScore()
+		return
 	}
 
-	// score board following by attributes form are shown at the end of round
+	// score board following by shop and attributes form are shown at the end of round
+	// shop is skipped when there is no human player who could buy something
 	score := r.game.Hud().ShowScore()
 	score.OnClose(func() {
-		attrs := r.game.Hud().ShowAttributes(false)
-		attrs.OnClose(func() {
-			r.Next()
-		})
+		attributes := func() {
+			attrs := r.game.Hud().ShowAttributes(false)
+			attrs.OnClose(func() {
+				r.Next()
+			})
+		}
+		if len(r.game.players.Humans()) == 0 {
+			attributes()
+			return
+		}
+		shop := r.game.Hud().ShowShop(entities.Goods)
+		shop.OnClose(attributes)
 	})
 }
 
@@ -108,53 +93,79 @@ func (r *Round) Tick(e tl.Event) {}
 
 // Restart will put state of this round to the same state as when it was started.
 func (r *Round) Restart() {
+	r.start(r.model.Index, r.model.StartingPlayer)
+}
+
+// Next will go to the next round.
+func (r *Round) Next() {
+	r.start(r.model.Index+1, (r.model.StartingPlayer+1)%len(r.game.players))
+}
+
+// start creates new world and model for the round with given index and starting player
+func (r *Round) start(index, startingPlayer int) {
 	// create world
 	r.world = entities.NewWorld(r.game, entities.WorldOptions{
 		Width:     r.game.options.Width,
 		Height:    r.game.options.Height,
-		Seed:      r.game.options.Seed + int64(r.index),
+		Seed:      r.game.options.Seed + int64(index),
 		ASCIIOnly: r.game.options.ASCIIOnly,
 		LowColor:  r.game.options.LowColor,
+		Wind:      r.game.options.Wind,
+		Colors:    r.game.options.Colors,
+		Tunables:  r.game.options.World,
 	})
 
 	// collect tanks for players
 	r.tanks = make([]*entities.Tank, len(r.game.players))
+	models := make([]*core.Tank, len(r.game.players))
 	for i, player := range r.game.players {
 		for _, e := range r.world.Entities {
 			if tank, ok := e.(*entities.Tank); ok && tank.Player() == player {
 				r.tanks[i] = tank
+				models[i] = tank.Model()
 			}
 		}
 	}
 
 	// round is started again
-	r.state = Started
-}
-
-// Next will go to the next round.
-func (r *Round) Next() {
-	r.index++
-	r.startingPlayerIndex = (r.startingPlayerIndex + 1) % len(r.game.players)
-	r.Restart()
+	r.model = core.NewRound(index, models, startingPlayer, r.game.Events())
 }
 
 // Number returns number of this round starting with 1 for the first round
 func (r *Round) Number() int {
-	return r.index + 1
+	return r.model.Index + 1
+}
+
+// World returns game world of this round
+func (r *Round) World() *entities.World {
+	return r.world
+}
+
+// Model returns engine independent model of this round
+func (r *Round) Model() *core.Round {
+	return r.model
+}
+
+// Tanks returns tanks of all players in the same order as players are
+func (r *Round) Tanks() []*entities.Tank {
+	return r.tanks
+}
+
+// ActivePlayerIndex returns index of the player which is currently on turn
+func (r *Round) ActivePlayerIndex() int {
+	return r.model.OnTurnPlayer
 }
 
 // ActiveTank returns tank which is currently active / on turn.
 func (r *Round) ActiveTank() *entities.Tank {
-	return r.tanks[r.onTurnPlayerIndex]
+	return r.tanks[r.model.OnTurnPlayer]
 }
 
-// IsTurnFinished returns true if there are no bullets and explosions in world
+// IsTurnFinished returns true if there are no bullets, explosions and no terrain being filled by dirt or growing walls in world
 func (r *Round) IsTurnFinished() bool {
 	for _, e := range r.world.Entities {
-		if _, ok := e.(*entities.Bullet); ok {
-			return false
-		}
-		if _, ok := e.(*entities.Explosion); ok {
+		switch e.(type) {
+		case *entities.Bullet, *entities.Explosion, *entities.Dirt, *entities.Wall:
 			return false
 		}
 	}
@@ -163,29 +174,41 @@ func (r *Round) IsTurnFinished() bool {
 
 // NumberOfTanksAlive returns how many tanks is still alive (in game).
 func (r *Round) NumberOfTanksAlive() int {
-	alive := 0
-	for _, t := range r.tanks {
-		if t.IsAlive() {
-			alive++
-		}
-	}
-	return alive
+	return r.model.NumberOfTanksAlive()
 }
 
-// ActivateNextTank moves turn to nearest tank which is alive.
-func (r *Round) ActivateNextTank() {
-	r.onTurnPlayerIndex = (r.onTurnPlayerIndex + 1) % len(r.tanks)
-	if !r.ActiveTank().IsAlive() && r.NumberOfTanksAlive() > 0 {
-		r.ActivateNextTank()
-	}
+// IsTurnInProgress returns true when player on turn already did his move and round waits for all it's consequences
+func (r *Round) IsTurnInProgress() bool {
+	return r.model.State == core.WaitForTurnFinish
 }
 
 // IsFinished returns true when round was already finished
 func (r *Round) IsFinished() bool {
-	return r.state == Finished
+	return r.model.State == core.Finished
 }
 
 // IsPlayerOnTurn returns turn when some player is on turn now and he didn't made his move yet
 func (r *Round) IsPlayerOnTurn() bool {
-	return r.state == PlayerOnTurn
+	return r.model.State == core.PlayerOnTurn
+}
+
+// Restore changes state of this round to the state from given snapshot.
+// If snapshot is from another round or this round is already finished the round is restarted first.
+func (r *Round) Restore(s *Snapshot) {
+	if r.model.Index != s.Round || r.model.State == core.Finished {
+		r.game.Hud().HideForm()
+		r.start(s.Round, s.StartingPlayer)
+	}
+	r.world.RestoreTerrain(s.Terrain)
+	for i, t := range s.Tanks {
+		if i < len(r.tanks) {
+			r.tanks[i].Restore(t)
+		}
+	}
+	r.world.SetWind(s.Wind)
+	r.model.OnTurnPlayer = s.OnTurnPlayer
+	r.model.MarkRestored()
+	if r.model.State != core.Started {
+		r.model.State = core.PlayerOnTurn
+	}
 }
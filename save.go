@@ -0,0 +1,76 @@
+package gorched
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SavedGame holds everything what is needed to resume the game later.
+// It's stored as JSON.
+type SavedGame struct {
+	// Options holds options used to create saved game
+	Options GameOptions
+	// Snapshot holds state of the players and of the round in which the game was saved
+	Snapshot *Snapshot
+}
+
+// Save writes actual state of the game to given writer.
+// Game can be saved only when some player is on turn because flying projectiles are not saved.
+func (g *Game) Save(w io.Writer) error {
+	if !g.round.IsPlayerOnTurn() {
+		return errors.New("Game can be saved only when some player is on turn")
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(SavedGame{Options: g.options, Snapshot: g.Snapshot()})
+}
+
+// SaveFile saves the game to the file set by SetSavePath.
+// File is replaced at once so previous save is never lost by half written file.
+func (g *Game) SaveFile() error {
+	if g.savePath == "" {
+		return errors.New("There is no file for saving the game")
+	}
+	tmp := g.savePath + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := g.Save(file); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, g.savePath)
+}
+
+// SetSavePath sets path of the file where the game will be saved by SaveFile
+func (g *Game) SetSavePath(path string) {
+	g.savePath = path
+}
+
+// SavePath returns path of the file where the game will be saved by SaveFile
+func (g *Game) SavePath() string {
+	return g.savePath
+}
+
+// LoadGame reads game saved by Game.Save
+func LoadGame(r io.Reader) (*SavedGame, error) {
+	saved := &SavedGame{}
+	if err := json.NewDecoder(r).Decode(saved); err != nil {
+		return nil, err
+	}
+	if saved.Snapshot == nil {
+		return nil, errors.New("Saved game has no state")
+	}
+	if len(saved.Snapshot.Players) != saved.Options.PlayerCount || len(saved.Snapshot.Tanks) != saved.Options.PlayerCount {
+		return nil, fmt.Errorf("Saved game should have %d players", saved.Options.PlayerCount)
+	}
+	return saved, nil
+}
@@ -0,0 +1,260 @@
+// Package server provides SSH server which allows to play the game directly from SSH client.
+//
+// Each SSH session runs the game in a separate process attached to the pseudo terminal.
+// Size of the terminal is taken from the session's pty request and it's updated on each window change request.
+//
+// After connecting, player can choose to start his own game or to join the shared hot-seat lobby.
+// Lobby is the network game (see network package) hosted by the first player who joined it.
+// Next players are joining it as remote players until the lobby is full.
+// Running shared game can be also watched by any number of spectators.
+// Choice can be skipped by using command `single`, `lobby` or `watch` when connecting, e.g. `ssh -t -p 2222 host lobby`.
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Options holds configuration of the SSH server
+type Options struct {
+	// Address where server will listen
+	Address string
+	// HostKeyPath is path to the file with private host key, it will be generated if it does not exist
+	HostKeyPath string
+	// LobbyPlayers is number of players in one lobby game
+	LobbyPlayers int
+	// GameArgs are additional command line arguments passed to each game process
+	GameArgs []string
+}
+
+// Server is SSH server running games for all connected sessions
+type Server struct {
+	// options holds server configuration
+	options Options
+	// config is configuration of SSH protocol
+	config *ssh.ServerConfig
+	// executable is path to the game binary started for each session
+	executable string
+	// lobby is currently opened lobby, nil if there is no opened lobby
+	lobby *lobby
+	// running holds all lobbies with running game, the latest started is the last one
+	running []*lobby
+	// lobbyMutex guards access to the lobbies
+	lobbyMutex sync.Mutex
+}
+
+// lobby is shared network game which players can join
+type lobby struct {
+	// address where lobby's game is hosted
+	address string
+	// joined is number of players already joined to the lobby
+	joined int
+}
+
+// NewServer creates new server with host key loaded or generated on given path
+func NewServer(o Options) (*Server, error) {
+	signer, err := loadOrGenerateHostKey(o.HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to prepare host key: %w", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+	return &Server{options: o, config: config, executable: executable}, nil
+}
+
+// ListenAndServe accepts SSH connections until some error occurs
+func (s *Server) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.options.Address)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle processes one SSH connection
+func (s *Server) handle(conn net.Conn) {
+	_, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for nc := range channels {
+		if nc.ChannelType() != "session" {
+			nc.Reject(ssh.UnknownChannelType, "only session channels are supported")
+			continue
+		}
+		channel, requests, err := nc.Accept()
+		if err != nil {
+			continue
+		}
+		go newSession(s, channel).serve(requests)
+	}
+}
+
+// joinLobby returns arguments for the game process to join the lobby.
+// If there is no opened lobby, new lobby is created and returned arguments will host it.
+// Returned function should be called after game process is finished.
+func (s *Server) joinLobby() ([]string, func(), error) {
+	s.lobbyMutex.Lock()
+	l := s.lobby
+	if l == nil {
+		defer s.lobbyMutex.Unlock()
+		return s.createLobby()
+	}
+	s.lobbyMutex.Unlock()
+
+	// waiting for the host is done without the lock to do not block other sessions
+	if err := waitForAddress(l.address, 5*time.Second); err != nil {
+		return nil, nil, err
+	}
+
+	// join existing lobby if it's still running and there is a free slot
+	s.lobbyMutex.Lock()
+	defer s.lobbyMutex.Unlock()
+	if !s.isRunning(l) {
+		return nil, nil, errors.New("Shared game was already finished")
+	}
+	if l.joined >= s.options.LobbyPlayers {
+		return nil, nil, errors.New("Shared game is already full")
+	}
+	l.joined++
+	if l.joined >= s.options.LobbyPlayers && s.lobby == l {
+		s.lobby = nil
+	}
+	return []string{"--join", l.address}, func() {
+		// slot of the player who left can be taken by someone else
+		s.lobbyMutex.Lock()
+		defer s.lobbyMutex.Unlock()
+		l.joined--
+		if s.lobby == nil && s.isRunning(l) {
+			s.lobby = l
+		}
+	}, nil
+}
+
+// createLobby creates new lobby and returns arguments for the game process to host it.
+// Returned function should be called after game process is finished.
+// It should be called only when lobbyMutex is locked.
+func (s *Server) createLobby() ([]string, func(), error) {
+	address, err := freeAddress()
+	if err != nil {
+		return nil, nil, err
+	}
+	l := &lobby{address: address, joined: 1}
+	s.lobby = l
+	s.running = append(s.running, l)
+	args := []string{"--host", address, "--players", fmt.Sprint(s.options.LobbyPlayers)}
+	return args, func() {
+		// lobby is closed when it's host is finished
+		s.lobbyMutex.Lock()
+		defer s.lobbyMutex.Unlock()
+		if s.lobby == l {
+			s.lobby = nil
+		}
+		for i, r := range s.running {
+			if r == l {
+				s.running = append(s.running[:i], s.running[i+1:]...)
+				break
+			}
+		}
+	}, nil
+}
+
+// isRunning returns true if game of given lobby is still running.
+// It should be called only when lobbyMutex is locked.
+func (s *Server) isRunning(l *lobby) bool {
+	for _, r := range s.running {
+		if r == l {
+			return true
+		}
+	}
+	return false
+}
+
+// watchLobby returns arguments for the game process to watch the latest started lobby
+func (s *Server) watchLobby() ([]string, error) {
+	s.lobbyMutex.Lock()
+	if len(s.running) == 0 {
+		s.lobbyMutex.Unlock()
+		return nil, errors.New("There is no running shared game")
+	}
+	l := s.running[len(s.running)-1]
+	s.lobbyMutex.Unlock()
+
+	// waiting for the host is done without the lock to do not block other sessions
+	if err := waitForAddress(l.address, 5*time.Second); err != nil {
+		return nil, err
+	}
+	return []string{"--join", l.address, "--spectate"}, nil
+}
+
+// freeAddress finds some free local address for hosting the lobby
+func freeAddress() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
+
+// waitForAddress waits until it's possible to connect to given address
+func waitForAddress(address string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := net.Dial("tcp", address)
+		if err == nil {
+			return conn.Close()
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// loadOrGenerateHostKey loads private key from given path.
+// If there is no file on given path new ed25519 key is generated and stored there.
+func loadOrGenerateHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return ssh.ParsePrivateKey(data)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	block, err := ssh.MarshalPrivateKey(key, "gorched host key")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		return nil, err
+	}
+	return ssh.NewSignerFromKey(key)
+}
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/creack/pty"
+	"golang.org/x/crypto/ssh"
+)
+
+// session is one SSH session running the game process
+type session struct {
+	// server is server which accepted this session
+	server *Server
+	// channel is SSH channel of this session
+	channel ssh.Channel
+	// term is value of TERM environment variable requested by client
+	term string
+	// size is actual size of the client's terminal
+	size pty.Winsize
+	// tty is pseudo terminal attached to the game process, nil until the game is started
+	tty *os.File
+	// mutex guards access to size and tty
+	mutex sync.Mutex
+}
+
+// ptyRequest is payload of "pty-req" request as defined in RFC 4254
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+// windowChangeRequest is payload of "window-change" request as defined in RFC 4254
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+// execRequest is payload of "exec" request as defined in RFC 4254
+type execRequest struct {
+	Command string
+}
+
+// newSession creates new session for given channel
+func newSession(s *Server, channel ssh.Channel) *session {
+	return &session{server: s, channel: channel, term: "xterm"}
+}
+
+// serve handles all requests of the session
+func (s *session) serve(requests <-chan *ssh.Request) {
+	started := false
+	for req := range requests {
+		ok := false
+		switch req.Type {
+		case "pty-req":
+			var r ptyRequest
+			if ssh.Unmarshal(req.Payload, &r) == nil {
+				if r.Term != "" {
+					s.term = r.Term
+				}
+				s.resize(r.Columns, r.Rows)
+				ok = true
+			}
+		case "window-change":
+			var r windowChangeRequest
+			if ssh.Unmarshal(req.Payload, &r) == nil {
+				s.resize(r.Columns, r.Rows)
+				ok = true
+			}
+		case "shell", "exec":
+			var r execRequest
+			if req.Type == "exec" {
+				ssh.Unmarshal(req.Payload, &r)
+			}
+			if !started && s.hasTerminal() {
+				started = true
+				ok = true
+				go s.run(strings.TrimSpace(r.Command))
+			}
+		}
+		if req.WantReply {
+			req.Reply(ok, nil)
+		}
+	}
+}
+
+// hasTerminal returns true if client requested pseudo terminal with known size
+func (s *session) hasTerminal() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.size.Cols > 0 && s.size.Rows > 0
+}
+
+// resize changes size of the client's terminal and propagates it to the game process
+func (s *session) resize(columns, rows uint32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.size = pty.Winsize{Cols: uint16(columns), Rows: uint16(rows)}
+	if s.tty != nil {
+		pty.Setsize(s.tty, &s.size)
+	}
+}
+
+// run starts the game process for given command and waits until it's finished.
+// Command can be "single" for own game, "lobby" for shared game or "watch" for watching shared game.
+// When command is empty player is asked to choose.
+func (s *session) run(command string) {
+	defer s.channel.Close()
+
+	if command == "" {
+		command = s.choose()
+	}
+
+	var args []string
+	done := func() {}
+	switch command {
+	case "single":
+	case "lobby":
+		var err error
+		args, done, err = s.server.joinLobby()
+		if err != nil {
+			s.exit(fmt.Sprintf("Unable to join lobby: %s", err), 1)
+			return
+		}
+	case "watch":
+		var err error
+		args, err = s.server.watchLobby()
+		if err != nil {
+			s.exit(fmt.Sprintf("Unable to watch shared game: %s", err), 1)
+			return
+		}
+	case "":
+		s.exit("", 0)
+		return
+	default:
+		s.exit(fmt.Sprintf("Unknown command '%s', use 'single', 'lobby' or 'watch'", command), 1)
+		return
+	}
+	defer done()
+
+	args = append(args, s.server.options.GameArgs...)
+	cmd := exec.Command(s.server.executable, args...)
+	cmd.Env = append(os.Environ(), "TERM="+s.term)
+
+	s.mutex.Lock()
+	tty, err := pty.StartWithSize(cmd, &s.size)
+	s.tty = tty
+	s.mutex.Unlock()
+	if err != nil {
+		s.exit(fmt.Sprintf("Unable to start game: %s", err), 1)
+		return
+	}
+	defer tty.Close()
+
+	go io.Copy(tty, s.channel)
+	io.Copy(s.channel, tty)
+
+	status := uint32(0)
+	if err := cmd.Wait(); err != nil {
+		status = 1
+	}
+	s.exit("", status)
+}
+
+// choose shows menu to the player and returns command according to his choice.
+// Empty string is returned when player decided to quit.
+func (s *session) choose() string {
+	fmt.Fprint(s.channel, "Welcome to GOrched !\r\n\r\n")
+	fmt.Fprint(s.channel, "  [1] Start own game\r\n")
+	fmt.Fprint(s.channel, "  [2] Join shared game\r\n")
+	fmt.Fprint(s.channel, "  [3] Watch shared game\r\n")
+	fmt.Fprint(s.channel, "  [q] Quit\r\n\r\n")
+	buf := make([]byte, 1)
+	for {
+		if _, err := s.channel.Read(buf); err != nil {
+			return ""
+		}
+		switch buf[0] {
+		case '1':
+			return "single"
+		case '2':
+			fmt.Fprint(s.channel, "Joining shared game...\r\n")
+			return "lobby"
+		case '3':
+			return "watch"
+		case 'q', 'Q', 3, 4:
+			return ""
+		}
+	}
+}
+
+// exit prints message to the client and sends exit status of the session
+func (s *session) exit(message string, status uint32) {
+	if message != "" {
+		fmt.Fprintf(s.channel, "%s\r\n", message)
+	}
+	s.channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+}
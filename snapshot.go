@@ -0,0 +1,64 @@
+package gorched
+
+import (
+	"github.com/zladovan/gorched/core"
+	"github.com/zladovan/gorched/entities"
+	"github.com/zladovan/gorched/entities/terrain"
+)
+
+// Snapshot holds state of the game which is needed to restore the game to the same state.
+// It can be used to synchronize the game between multiple processes.
+type Snapshot struct {
+	// Round is index of the round starting from zero
+	Round int
+	// StartingPlayer is index of the player which was first on turn in the round
+	StartingPlayer int
+	// OnTurnPlayer is index of the player currently on turn
+	OnTurnPlayer int
+	// Players holds all players in the game
+	Players []core.Player
+	// Terrain holds layout of all terrain columns
+	Terrain terrain.Snapshot
+	// Tanks holds state of the tank for each player
+	Tanks []entities.TankSnapshot
+	// Wind is actual strength of the wind
+	Wind int
+}
+
+// Snapshot returns current state of the game
+func (g *Game) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Round:          g.round.model.Index,
+		StartingPlayer: g.round.model.StartingPlayer,
+		OnTurnPlayer:   g.round.model.OnTurnPlayer,
+		Terrain:        g.round.world.Terrain().Snapshot(),
+		Wind:           g.round.world.Wind(),
+	}
+	for _, p := range g.players {
+		c := *p
+		c.Inventory = p.Inventory.Copy()
+		s.Players = append(s.Players, c)
+	}
+	for _, t := range g.round.tanks {
+		s.Tanks = append(s.Tanks, t.Snapshot())
+	}
+	return s
+}
+
+// Restore changes state of the game to the state from given snapshot.
+// If snapshot is from different round, the round is restarted first.
+// Type of the players is not changed as it depends on the process where the game is running.
+func (g *Game) Restore(s *Snapshot) {
+	for i, p := range s.Players {
+		if i >= len(g.players) {
+			break
+		}
+		g.players[i].Name = p.Name
+		g.players[i].Stats = p.Stats
+		g.players[i].Attributes = p.Attributes
+		g.players[i].Inventory = p.Inventory
+		g.players[i].Weapon = p.Weapon
+		g.players[i].Money = p.Money
+	}
+	g.round.Restore(s)
+}